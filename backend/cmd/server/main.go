@@ -31,7 +31,7 @@ func main() {
 	defer app.Close()
 
 	app.Logger.Info("Application initialized successfully",
-		"version", "1.0.0",
+		"version", config.Version,
 		"log_level", cfg.Log.Level,
 		"log_format", cfg.Log.Format,
 	)
@@ -43,6 +43,8 @@ func main() {
 		}
 	}()
 
+	app.StartJobs()
+
 	app.Logger.Info("Server started successfully")
 
 	quit := make(chan os.Signal, 1)
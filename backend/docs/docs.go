@@ -18,46 +18,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/clients": {
+        "/api/admin/campaigns": {
             "get": {
-                "description": "Возвращает список всех клиентов с пагинацией (offset-based)",
+                "description": "Возвращает все промо-кампании, включая завершенные и отключенные, с числом выданных предложений",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
-                ],
-                "summary": "Список клиентов",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Количество записей (по умолчанию 100, макс 1000)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Смещение (по умолчанию 0)",
-                        "name": "offset",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "Промо-кампании",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.ClientResponse"
+                                "$ref": "#/definitions/dto.PromoCampaignResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -67,7 +47,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает нового клиента с переданными данными (ФИО, дата рождения, признаки для ML)",
+                "description": "Создает кампанию в категории промо. В период действия кампания с наибольшим приоритетом, у которой выполнены все условия таргетинга и не исчерпан лимит выдач, заменяет предложение по диапазону дохода.",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,17 +55,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "admin"
                 ],
-                "summary": "Создание клиента",
+                "summary": "Создание промо-кампании",
                 "parameters": [
                     {
-                        "description": "Данные клиента",
+                        "description": "Кампания и условия таргетинга",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateClientRequest"
+                            "$ref": "#/definitions/dto.PromoCampaignRequest"
                         }
                     }
                 ],
@@ -93,7 +73,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.ClientResponse"
+                            "$ref": "#/definitions/dto.PromoCampaignResponse"
                         }
                     },
                     "400": {
@@ -102,6 +82,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -111,33 +97,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/clients/import": {
-            "post": {
-                "description": "Загружает клиентов из CSV файла. Поддерживает две схемы: простую (first_name,last_name,birth_date,...) и полную (с features в JSON). Формат даты: DD-MM-YYYY или YYYY-MM-DD",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/api/admin/campaigns/{id}": {
+            "get": {
+                "description": "Возвращает промо-кампанию с условиями таргетинга",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "admin"
                 ],
-                "summary": "Импорт клиентов из CSV",
+                "summary": "Промо-кампания",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "CSV файл с данными клиентов",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Результат импорта с количеством успешных/неудачных записей",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/interfaces.ImportStats"
+                            "$ref": "#/definitions/dto.PromoCampaignResponse"
                         }
                     },
                     "400": {
@@ -146,6 +129,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -153,52 +142,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/clients/search": {
-            "get": {
-                "description": "Поиск по ФИО и дате рождения (формат даты: DD-MM-YYYY). Требуется хотя бы один параметр.",
+            },
+            "put": {
+                "description": "Обновляет кампанию целиком. Счетчик выданных предложений сохраняется.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "admin"
                 ],
-                "summary": "Поиск клиентов",
+                "summary": "Обновление промо-кампании",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя (частичное совпадение)",
-                        "name": "first_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фамилия (частичное совпадение)",
-                        "name": "last_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Отчество (частичное совпадение)",
-                        "name": "middle_name",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Дата рождения (DD-MM-YYYY)",
-                        "name": "birth_date",
-                        "in": "query"
+                        "description": "Кампания и условия таргетинга",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ClientResponse"
-                            }
+                            "$ref": "#/definitions/dto.PromoCampaignResponse"
                         }
                     },
                     "400": {
@@ -207,6 +186,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -214,22 +205,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/clients/{id}": {
-            "get": {
-                "description": "Возвращает полные данные клиента по его ID",
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "admin"
                 ],
-                "summary": "Получение клиента",
+                "summary": "Удаление промо-кампании",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Client ID",
+                        "description": "Campaign ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -239,7 +227,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ClientResponse"
+                            "$ref": "#/definitions/dto.SuccessResponse"
                         }
                     },
                     "400": {
@@ -261,52 +249,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные существующего клиента. Все поля опциональны.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/admin/drift": {
+            "get": {
+                "description": "PSI и изменение доли пропусков (импутированных значений) по каждому числовому признаку и по прогнозу дохода за последние drift.window_days дней относительно эталона. Признаки отсортированы по убыванию PSI, превышения порогов помечены alert и пишутся в лог.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
-                ],
-                "summary": "Обновление клиента",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Client ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateClientRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Дрейф данных",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ClientResponse"
+                            "$ref": "#/definitions/dto.DriftReport"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -318,42 +287,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет клиента из базы данных по его ID",
+            }
+        },
+        "/api/admin/drift/reference": {
+            "post": {
+                "description": "Строит эталонные распределения (квантильные бины и доля пропусков) по скорингам за период и заменяет ими прежний эталон. По умолчанию период - последние 30 дней.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "admin"
                 ],
-                "summary": "Удаление клиента",
+                "summary": "Эталон дрейфа",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Client ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Начало периода (DD-MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (DD-MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.DriftReferenceResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
                     },
                     "500": {
@@ -365,43 +335,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/clients/{id}/scoring": {
+        "/api/admin/model-quality": {
             "get": {
-                "description": "Запускает ML-модель для расчета скора клиента и получения рекомендаций",
+                "description": "MAE, MAPE (%) и bias (прогноз - факт) прогноза дохода относительно подтвержденного дохода в целом и в разбивке по версии модели, диапазону подтвержденного дохода, региону и возрастной группе. Период - по дате подтверждения дохода, по умолчанию последние 90 дней.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "scoring"
+                    "admin"
                 ],
-                "summary": "Расчет ML-скоринга",
+                "summary": "Качество модели",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Client ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Начало периода (DD-MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (DD-MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ScoringResponse"
+                            "$ref": "#/definitions/dto.ModelQualityResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/admin/models/comparison": {
+            "get": {
+                "description": "Настроенные варианты модели и их доля трафика, число скорингов и средние прогноз и лимит по боевым вариантам, а также расхождение теневых вариантов с боевым на одних и тех же скорингах: доля согласных прогнозов (относительная разница не больше ml.routing.agreement_tolerance), средняя и средняя абсолютная разница прогноза и лимита (challenger - champion), доли скорингов с большим и меньшим лимитом. По умолчанию последние 30 дней.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Сравнение вариантов модели",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (DD-MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (DD-MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelComparisonResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -412,96 +427,2566 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/admin/promos": {
             "get": {
-                "description": "Возвращает статус работоспособности API",
+                "description": "Возвращает категории промо-предложений с диапазонами дохода, отсортированные по sort_order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Проверка здоровья сервиса",
+                "summary": "Каталог промо",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PromoCategoryResponse"
                             }
                         }
-                    }
-                }
-            }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает категорию с диапазонами дохода. Диапазоны [min_income, max_income) не должны пересекаться и оставлять разрывы.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Создание категории промо",
+                "parameters": [
+                    {
+                        "description": "Категория и диапазоны",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/promos/stats": {
+            "get": {
+                "description": "Показы, принятия, отказы и конверсия (accepted / impressions) по каждому промо и диапазону прогноза дохода (promo.stats_income_bands) за период. По умолчанию - последние 30 дней.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Конверсия промо",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (DD-MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (DD-MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoStatsResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/promos/{id}": {
+            "get": {
+                "description": "Возвращает категорию промо-предложений с диапазонами дохода",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Категория промо",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет категорию и ее диапазоны дохода: диапазоны с id обновляются, без id создаются, не переданные удаляются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Обновление категории промо",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Категория и диапазоны",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет категорию вместе с ее диапазонами дохода",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Удаление категории промо",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/training/stats": {
+            "get": {
+                "description": "Число размеченных примеров по статусам отправки в ML-сервис и время последней доставки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Выгрузка примеров для дообучения",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrainingDeliveryStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients": {
+            "get": {
+                "description": "Возвращает список всех клиентов с пагинацией (offset-based)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Список клиентов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 100, макс 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение (по умолчанию 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ClientResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает нового клиента с переданными данными (ФИО, дата рождения, признаки для ML)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Создание клиента",
+                "parameters": [
+                    {
+                        "description": "Данные клиента",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateClientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/import": {
+            "post": {
+                "description": "Загружает клиентов из CSV файла. Поддерживает две схемы: простую (first_name,last_name,birth_date,...) и полную (с features в JSON). Формат даты: DD-MM-YYYY или YYYY-MM-DD",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Импорт клиентов из CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV файл с данными клиентов",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат импорта с количеством успешных/неудачных записей",
+                        "schema": {
+                            "$ref": "#/definitions/interfaces.ImportStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/search": {
+            "get": {
+                "description": "Поиск по ФИО и дате рождения (формат даты: DD-MM-YYYY). Требуется хотя бы один параметр.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Поиск клиентов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя (частичное совпадение)",
+                        "name": "first_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фамилия (частичное совпадение)",
+                        "name": "last_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Отчество (частичное совпадение)",
+                        "name": "middle_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата рождения (DD-MM-YYYY)",
+                        "name": "birth_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ClientResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}": {
+            "get": {
+                "description": "Возвращает полные данные клиента по его ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Получение клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные существующего клиента. Все поля опциональны.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Обновление клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateClientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет клиента из базы данных по его ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Удаление клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/income-confirmation": {
+            "post": {
+                "description": "Сохраняет фактический доход клиента и размечает им признаки последнего скоринга. Размеченные примеры периодически отправляются в ML-сервис для дообучения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Подтверждение дохода",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Подтвержденный доход",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IncomeConfirmationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IncomeConfirmationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/offers": {
+            "get": {
+                "description": "Проверяет сумму относительно лимитов клиента из последнего скоринга (при его отсутствии скоринг считается заново) и возвращает по каждому продукту ставку, ежемесячный платеж, переплату, ПДН и аннуитетный график платежей",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Кредитные предложения",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Сумма кредита",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Срок в месяцах, не больше 600",
+                        "name": "term",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип продукта (по умолчанию все)",
+                        "name": "product",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OffersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Сумма выше лимита или нет подходящего тарифа",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ML-сервис недоступен, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/promos/{promoId}/accept": {
+            "post": {
+                "description": "Записывает согласие клиента на последний показ промо. promoId - id рекомендации из скоринга (band-\u003cid\u003e или campaign-\u003cid\u003e).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promos"
+                ],
+                "summary": "Клиент принял промо",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo ID",
+                        "name": "promoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoImpressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Промо не показывалось клиенту",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Реакция на показ уже записана",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/promos/{promoId}/decline": {
+            "post": {
+                "description": "Записывает отказ клиента от последнего показа промо. promoId - id рекомендации из скоринга (band-\u003cid\u003e или campaign-\u003cid\u003e).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promos"
+                ],
+                "summary": "Клиент отказался от промо",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo ID",
+                        "name": "promoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoImpressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Промо не показывалось клиенту",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Реакция на показ уже записана",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/scoring": {
+            "get": {
+                "description": "Запускает ML-модель для расчета скора клиента и получения рекомендаций. Если ML-сервис недоступен, а признаки клиента не менялись с последнего скоринга, возвращается его прогноз с stale: true и возрастом stale_age_seconds; лимит и промо считаются заново (scoring.stale_fallback).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Расчет ML-скоринга",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить детализацию расчета лимита",
+                        "name": "breakdown",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScoringResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "У клиента нет признаков или недостаточно данных (scoring.reject_insufficient_data)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ML-сервис недоступен, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/scoring/simulate": {
+            "post": {
+                "description": "Накладывает переопределения признаков на сохраненные признаки клиента и возвращает результат рядом с базовым расчетом. Клиент и история скорингов не изменяются. Значение null сбрасывает признак к значению по умолчанию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "What-if скоринг",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Переопределения признаков",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SimulationRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить детализацию расчета лимита",
+                        "name": "breakdown",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SimulationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ML-сервис недоступен, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/scorings": {
+            "get": {
+                "description": "Возвращает сохраненные результаты скоринга клиента, от новых к старым, с пагинацией (offset-based)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "История скорингов клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 100, макс 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение (по умолчанию 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScoringHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/clients/{id}/scorings/latest": {
+            "get": {
+                "description": "Возвращает последний сохраненный результат скоринга без повторного вызова ML-сервиса",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Последний скоринг клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScoringRecordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/features/schema": {
+            "get": {
+                "description": "Возвращает версионированную схему признаков: тип (numeric/categorical/flag), допустимый диапазон или список значений, значение по умолчанию для заполнения пропусков, описание и версию модели",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "features"
+                ],
+                "summary": "Схема признаков",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeatureSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scoring": {
+            "post": {
+                "description": "Рассчитывает скоринг по данным и признакам, переданным в запросе, для человека, который еще не заведен как клиент. Ничего не сохраняет, поле id в ответе равно 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Скоринг заявителя",
+                "parameters": [
+                    {
+                        "description": "Данные заявителя и признаки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApplicantScoringRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить детализацию расчета лимита",
+                        "name": "breakdown",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScoringResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ML-сервис недоступен, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scoring/batch": {
+            "post": {
+                "description": "Рассчитывает скоринг для списка ID клиентов или для клиентов, найденных по фильтру. Ошибки по отдельным клиентам (not_found, no_features, ml_failure) возвращаются в errors и не прерывают пакет.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Пакетный скоринг",
+                "parameters": [
+                    {
+                        "description": "ID клиентов или фильтр поиска",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchScoringRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить детализацию расчета лимита",
+                        "name": "breakdown",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchScoringResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Возвращает статус работоспособности API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Проверка здоровья сервиса",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Отвечает 200, пока процесс обрабатывает запросы. Зависимости не проверяются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness-проба",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Пингует пул соединений Postgres и вызывает health check ML-сервиса с таймаутом health.timeout. Возвращает статус и задержку каждой зависимости и версию сборки; 503, если недоступна обязательная зависимость.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness-проба",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "dto.ApplicantScoringRequest": {
+            "type": "object",
+            "required": [
+                "birth_date",
+                "first_name",
+                "last_name"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "middle_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.BatchScoringError": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BatchScoringRequest": {
+            "type": "object",
+            "properties": {
+                "client_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "filter": {
+                    "$ref": "#/definitions/dto.SearchParams"
+                }
+            }
+        },
+        "dto.BatchScoringResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchScoringError"
+                    }
+                },
+                "failure_count": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScoringResponse"
+                    }
+                },
+                "success_count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.ClientResponse": {
             "type": "object",
             "properties": {
-                "birth_date": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "income": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "middle_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateClientRequest": {
+            "type": "object",
+            "required": [
+                "birth_date",
+                "first_name",
+                "last_name"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "middle_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreditLimitBreakdown": {
+            "type": "object",
+            "properties": {
+                "active_cc_max_limit": {
+                    "type": "number"
+                },
+                "amortization_months": {
+                    "type": "integer"
+                },
+                "bank_limit_before_rules": {
+                    "type": "number"
+                },
+                "bank_monthly_headroom": {
+                    "type": "number"
+                },
+                "bank_pdn": {
+                    "type": "number"
+                },
+                "blacklist_flag": {
+                    "type": "integer"
+                },
+                "denominator": {
+                    "type": "number"
+                },
+                "denominator_source": {
+                    "type": "string"
+                },
+                "existing_payment": {
+                    "type": "number"
+                },
+                "legal_limit_before_rules": {
+                    "type": "number"
+                },
+                "legal_monthly_headroom": {
+                    "type": "number"
+                },
+                "legal_pdn": {
+                    "type": "number"
+                },
+                "outstand_sum": {
+                    "type": "number"
+                },
+                "overdue_sum": {
+                    "type": "number"
+                },
+                "policy_version": {
+                    "type": "string"
+                },
+                "predicted_income": {
+                    "type": "number"
+                },
+                "zeroed": {
+                    "type": "boolean"
+                },
+                "zeroed_reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DeclineReason": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DependencyStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ]
+                }
+            }
+        },
+        "dto.DriftItem": {
+            "type": "object",
+            "properties": {
+                "alert": {
+                    "type": "boolean"
+                },
+                "alert_reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "psi",
+                            "missing_rate"
+                        ]
+                    }
+                },
+                "current_missing_rate": {
+                    "type": "number"
+                },
+                "missing_rate_delta": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "psi": {
+                    "type": "number"
+                },
+                "reference_missing_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.DriftReferenceResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "integer"
+                },
+                "sample_size": {
+                    "type": "integer"
+                },
+                "window_from": {
+                    "type": "string"
+                },
+                "window_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DriftReport": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "integer"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DriftItem"
+                    }
+                },
+                "prediction": {
+                    "$ref": "#/definitions/dto.DriftItem"
+                },
+                "reference": {
+                    "$ref": "#/definitions/dto.DriftReferenceResponse"
+                },
+                "sample_size": {
+                    "type": "integer"
+                },
+                "thresholds": {
+                    "$ref": "#/definitions/dto.DriftThresholds"
+                },
+                "window_from": {
+                    "type": "string"
+                },
+                "window_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DriftThresholds": {
+            "type": "object",
+            "properties": {
+                "missing_rate": {
+                    "type": "number"
+                },
+                "psi": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Factor": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "type": "number"
+                },
+                "feature": {
+                    "type": "string"
+                },
+                "label_ru": {
+                    "type": "string"
+                },
+                "value": {}
+            }
+        },
+        "dto.FactorCategory": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "contribution": {
+                    "type": "number"
+                },
+                "feature_count": {
+                    "type": "integer"
+                },
+                "share": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.IncomeConfirmationRequest": {
+            "type": "object",
+            "required": [
+                "confirmed_at",
+                "income",
+                "source"
+            ],
+            "properties": {
+                "confirmed_at": {
+                    "type": "string",
+                    "example": "15-09-2024"
+                },
+                "income": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "payslip",
+                        "bank_statement",
+                        "tax_return",
+                        "salary_project",
+                        "other"
+                    ]
+                }
+            }
+        },
+        "dto.IncomeConfirmationResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer"
+                },
+                "confirmed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "income": {
+                    "type": "number"
+                },
+                "scoring_id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "training_sample_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "alive"
+                    ]
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoanOffer": {
+            "type": "object",
+            "properties": {
+                "above_recommended_limit": {
+                    "type": "boolean"
+                },
+                "annual_rate": {
+                    "type": "number"
+                },
+                "monthly_payment": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pdn": {
+                    "type": "number"
+                },
+                "pdn_exceeds_limit": {
+                    "type": "boolean"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScheduleItem"
+                    }
+                },
+                "total_interest": {
+                    "type": "number"
+                },
+                "total_payment": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ModelComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "agreement_tolerance": {
+                    "type": "number"
+                },
+                "comparisons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShadowComparison"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "traffic": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VariantTraffic"
+                    }
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ModelVariantResponse"
+                    }
+                }
+            }
+        },
+        "dto.ModelQualityResponse": {
+            "type": "object",
+            "properties": {
+                "by_age_group": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QualityGroup"
+                    }
+                },
+                "by_income_band": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QualityGroup"
+                    }
+                },
+                "by_model_version": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QualityGroup"
+                    }
+                },
+                "by_region": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QualityGroup"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "overall": {
+                    "$ref": "#/definitions/dto.QualityMetrics"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ModelVariantResponse": {
+            "type": "object",
+            "properties": {
+                "model_version": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pipeline_version": {
+                    "type": "string"
+                },
+                "shadow": {
+                    "type": "boolean"
+                },
+                "traffic_share": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.OffersResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "existing_payment": {
+                    "type": "number"
+                },
+                "max_credit_limit": {
+                    "type": "number"
+                },
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LoanOffer"
+                    }
+                },
+                "predict_income": {
+                    "type": "number"
+                },
+                "term": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PromoBandRequest": {
+            "type": "object",
+            "properties": {
+                "cta_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_income": {
+                    "type": "integer"
+                },
+                "min_income": {
+                    "type": "integer"
+                },
+                "promo": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoBandResponse": {
+            "type": "object",
+            "properties": {
+                "cta_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_income": {
+                    "type": "integer"
+                },
+                "min_income": {
+                    "type": "integer"
+                },
+                "promo": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoCampaignRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "name",
+                "promo",
+                "starts_at"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "cta_url": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "max_issued": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "promo": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "targeting": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TargetingRule"
+                    }
+                }
+            }
+        },
+        "dto.PromoCampaignResponse": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cta_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_count": {
+                    "type": "integer"
+                },
+                "max_issued": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "promo": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "targeting": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TargetingRule"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoCategoryRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromoBandRequest"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "promo",
+                        "card",
+                        "investment"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PromoCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromoBandResponse"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoImpressionResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "promo_id": {
+                    "type": "string"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "scoring_id": {
+                    "type": "integer"
+                },
+                "shown_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "shown",
+                        "accepted",
+                        "declined"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoIncomeBandStats": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "declined": {
+                    "type": "integer"
+                },
+                "impressions": {
+                    "type": "integer"
+                },
+                "max_income": {
+                    "type": "number"
+                },
+                "min_income": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.PromoStats": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "declined": {
+                    "type": "integer"
+                },
+                "impressions": {
+                    "type": "integer"
+                },
+                "income_bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromoIncomeBandStats"
+                    }
+                },
+                "promo_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromoStatsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromoStats"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.QualityGroup": {
+            "type": "object",
+            "properties": {
+                "bias": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "mae": {
+                    "type": "number"
+                },
+                "mape": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.QualityMetrics": {
+            "type": "object",
+            "properties": {
+                "bias": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "mae": {
+                    "type": "number"
+                },
+                "mape": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyStatus"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ready",
+                        "not_ready"
+                    ]
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Recommendation": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "promo",
+                        "card",
+                        "investment"
+                    ]
+                },
+                "cta_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eligibility_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ScheduleItem": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "interest": {
+                    "type": "number"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "payment": {
+                    "type": "number"
+                },
+                "principal": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ScoringDelta": {
+            "type": "object",
+            "properties": {
+                "credit_limit": {
+                    "type": "number"
+                },
+                "max_credit_limit": {
+                    "type": "number"
+                },
+                "predict_income": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ScoringHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScoringRecordResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ScoringRecordResponse": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "$ref": "#/definitions/dto.CreditLimitBreakdown"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "data_completeness": {
+                    "type": "number"
+                },
+                "decision": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "decline_reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeclineReason"
+                    }
+                },
+                "factor_categories": {
+                    "description": "FactorCategories вклад по категориям признаков; пуст в записях до появления категорий",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FactorCategory"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imputed_features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "insufficient_data": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "integer"
+                "max_credit_limit": {
+                    "type": "number"
                 },
-                "income": {
-                    "type": "integer"
+                "missing_important_features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "last_name": {
+                "ml_uid": {
                     "type": "string"
                 },
-                "middle_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreateClientRequest": {
-            "type": "object",
-            "required": [
-                "birth_date",
-                "first_name",
-                "last_name"
-            ],
-            "properties": {
-                "birth_date": {
+                "model_variant": {
                     "type": "string"
                 },
-                "features": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "first_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "model_version": {
+                    "type": "string"
                 },
-                "last_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "negative_factors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Factor"
+                    }
                 },
-                "middle_name": {
-                    "type": "string",
-                    "maxLength": 100
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "pipeline_version": {
                     "type": "string"
                 },
-                "error": {
+                "policy_version": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
+                "positive_factors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Factor"
+                    }
+                },
+                "predict_income": {
+                    "type": "number"
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Recommendation"
+                    }
                 }
             }
         },
@@ -511,9 +2996,34 @@ const docTemplate = `{
                 "birth_date": {
                     "type": "string"
                 },
+                "breakdown": {
+                    "$ref": "#/definitions/dto.CreditLimitBreakdown"
+                },
+                "cached": {
+                    "description": "Cached прогноз взят из кэша, ScoredAt - время его расчета в ML",
+                    "type": "boolean"
+                },
                 "credit_limit": {
                     "type": "number"
                 },
+                "data_completeness": {
+                    "type": "number"
+                },
+                "decision": {
+                    "type": "string"
+                },
+                "decline_reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeclineReason"
+                    }
+                },
+                "factor_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FactorCategory"
+                    }
+                },
                 "first_name": {
                     "type": "string"
                 },
@@ -523,6 +3033,9 @@ const docTemplate = `{
                 "income": {
                     "type": "integer"
                 },
+                "insufficient_data": {
+                    "type": "boolean"
+                },
                 "last_name": {
                     "type": "string"
                 },
@@ -532,16 +3045,29 @@ const docTemplate = `{
                 "middle_name": {
                     "type": "string"
                 },
-                "negative_factors": {
+                "missing_important_features": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "model_variant": {
+                    "description": "ModelVariant вариант модели champion/challenger, которым посчитан прогноз",
+                    "type": "string"
+                },
+                "negative_factors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Factor"
+                    }
+                },
+                "policy_version": {
+                    "type": "string"
+                },
                 "positive_factors": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.Factor"
                     }
                 },
                 "predict_income": {
@@ -550,8 +3076,100 @@ const docTemplate = `{
                 "recommendations": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.Recommendation"
                     }
+                },
+                "scored_at": {
+                    "type": "string"
+                },
+                "stale": {
+                    "description": "Stale ML недоступен и прогноз взят из последнего сохраненного скоринга\nклиента; StaleAgeSeconds - его возраст. Лимит и промо посчитаны заново.",
+                    "type": "boolean"
+                },
+                "stale_age_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SearchParams": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "middle_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ShadowComparison": {
+            "type": "object",
+            "properties": {
+                "agreement_rate": {
+                    "type": "number"
+                },
+                "challenger": {
+                    "type": "string"
+                },
+                "champion": {
+                    "type": "string"
+                },
+                "higher_limit_rate": {
+                    "type": "number"
+                },
+                "lower_limit_rate": {
+                    "type": "number"
+                },
+                "mean_abs_income_diff": {
+                    "type": "number"
+                },
+                "mean_abs_limit_diff": {
+                    "type": "number"
+                },
+                "mean_income_diff": {
+                    "type": "number"
+                },
+                "mean_limit_diff": {
+                    "type": "number"
+                },
+                "pairs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SimulationRequest": {
+            "type": "object",
+            "properties": {
+                "overrides": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "dto.SimulationResponse": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "$ref": "#/definitions/dto.ScoringResponse"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "delta": {
+                    "$ref": "#/definitions/dto.ScoringDelta"
+                },
+                "overrides": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "simulated": {
+                    "$ref": "#/definitions/dto.ScoringResponse"
                 }
             }
         },
@@ -564,6 +3182,26 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TrainingDeliveryStatsResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "sending": {
+                    "type": "integer"
+                },
+                "sent": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.UpdateClientRequest": {
             "type": "object",
             "properties": {
@@ -590,6 +3228,51 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ValidationError"
+                    }
+                }
+            }
+        },
+        "dto.VariantTraffic": {
+            "type": "object",
+            "properties": {
+                "avg_credit_limit": {
+                    "type": "number"
+                },
+                "avg_predict_income": {
+                    "type": "number"
+                },
+                "scorings": {
+                    "type": "integer"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
         "interfaces.ImportStats": {
             "type": "object",
             "properties": {
@@ -609,6 +3292,84 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.FeatureDefinition": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "important": {
+                    "type": "boolean"
+                },
+                "label_ru": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "model_version": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.FeatureType"
+                }
+            }
+        },
+        "models.FeatureSchema": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeatureDefinition"
+                    }
+                },
+                "model_version": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeatureType": {
+            "type": "string",
+            "enum": [
+                "numeric",
+                "categorical",
+                "flag"
+            ],
+            "x-enum-varnames": [
+                "FeatureNumeric",
+                "FeatureCategorical",
+                "FeatureFlag"
+            ]
+        },
+        "models.TargetingRule": {
+            "type": "object",
+            "properties": {
+                "feature": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "value": {}
+            }
         }
     }
 }`
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+)
+
+// ScoreApplicant считает скоринг заявителя по данным из запроса.
+// Заявитель не сохраняется в clients, а результат - в историю скорингов.
+func (s *scoringService) ScoreApplicant(ctx context.Context, req *dto.ApplicantScoringRequest) (*dto.ScoringResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", domainerrors.ErrInvalidInput)
+	}
+
+	s.logger.Debug("Scoring applicant", "features", len(req.Features))
+
+	if err := validateFeatures(s.featureSchema, req.Features); err != nil {
+		s.logger.Warn("Applicant features validation failed", "error", err)
+		return nil, err
+	}
+
+	applicant, err := req.ToModel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert applicant: %w: %w", domainerrors.ErrInvalidFormat, err)
+	}
+	if !applicant.IsValid() {
+		return nil, fmt.Errorf("%w: applicant has invalid fields", domainerrors.ErrInvalidInput)
+	}
+
+	features, err := s.extractFeatures(applicant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract features: %w", err)
+	}
+
+	response, _, err := s.evaluate(ctx, applicant, features, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Applicant scored", "score", response.PredictIncome, "credit_limit", response.RecommendationCreditLimit)
+	return response, nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+const (
+	defaultBatchConcurrency = 8
+	defaultBatchMaxSize     = 1000
+)
+
+type batchJob struct {
+	index    int
+	clientID int64
+	client   *models.Client
+}
+
+type batchOutcome struct {
+	result *dto.ScoringResponse
+	err    *dto.BatchScoringError
+}
+
+func (s *scoringService) CalculateBatchScoring(ctx context.Context, req *dto.BatchScoringRequest) (*dto.BatchScoringResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", domainerrors.ErrInvalidInput)
+	}
+
+	jobs, err := s.collectBatchJobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := s.cfg.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+
+	s.logger.Info("Starting batch scoring", "clients", len(jobs), "concurrency", concurrency)
+
+	outcomes := make([]batchOutcome, len(jobs))
+	jobsCh := make(chan batchJob)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobsCh {
+				outcomes[job.index] = s.runBatchJob(ctx, job)
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		jobsCh <- job
+	}
+	close(jobsCh)
+	wg.Wait()
+
+	response := &dto.BatchScoringResponse{
+		Total:   len(jobs),
+		Results: make([]*dto.ScoringResponse, 0, len(jobs)),
+		Errors:  []dto.BatchScoringError{},
+	}
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			response.Errors = append(response.Errors, *outcome.err)
+			continue
+		}
+		response.Results = append(response.Results, outcome.result)
+	}
+	response.SuccessCount = len(response.Results)
+	response.FailureCount = len(response.Errors)
+
+	s.logger.Info("Batch scoring completed", "success", response.SuccessCount, "failures", response.FailureCount)
+	return response, nil
+}
+
+// collectBatchJobs превращает запрос в список клиентов для скоринга: явный список ID
+// (клиенты догружаются в воркерах) либо результат поиска по фильтру
+func (s *scoringService) collectBatchJobs(ctx context.Context, req *dto.BatchScoringRequest) ([]batchJob, error) {
+	hasIDs := len(req.ClientIDs) > 0
+	hasFilter := req.Filter != nil && !req.Filter.IsEmpty()
+
+	if hasIDs == hasFilter {
+		return nil, fmt.Errorf("%w: either client_ids or filter must be provided", domainerrors.ErrInvalidInput)
+	}
+
+	maxSize := s.cfg.BatchMaxSize
+	if maxSize <= 0 {
+		maxSize = defaultBatchMaxSize
+	}
+
+	var jobs []batchJob
+	if hasIDs {
+		seen := make(map[int64]bool, len(req.ClientIDs))
+		for _, id := range req.ClientIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			jobs = append(jobs, batchJob{index: len(jobs), clientID: id})
+		}
+	} else {
+		// на одного клиента больше лимита - чтобы отличить переполнение, не загружая всех
+		clients, err := s.clientRepo.Search(ctx, *req.Filter, maxSize+1)
+		if err != nil {
+			s.logger.Error("Failed to search clients for batch scoring", "error", err)
+			return nil, fmt.Errorf("failed to search clients: %w", err)
+		}
+		if len(clients) > maxSize {
+			return nil, fmt.Errorf("%w: filter matches more than %d clients", domainerrors.ErrInvalidInput, maxSize)
+		}
+		for i := range clients {
+			jobs = append(jobs, batchJob{index: len(jobs), clientID: clients[i].ID, client: &clients[i]})
+		}
+	}
+
+	if len(jobs) > maxSize {
+		return nil, fmt.Errorf("%w: batch contains %d clients, maximum is %d", domainerrors.ErrInvalidInput, len(jobs), maxSize)
+	}
+
+	return jobs, nil
+}
+
+func (s *scoringService) runBatchJob(ctx context.Context, job batchJob) batchOutcome {
+	if err := ctx.Err(); err != nil {
+		return batchOutcome{err: newBatchError(job.clientID, err)}
+	}
+
+	client := job.client
+	if client == nil {
+		loaded, err := s.clientRepo.GetByID(ctx, job.clientID)
+		if err != nil {
+			return batchOutcome{err: newBatchError(job.clientID, err)}
+		}
+		client = loaded
+	}
+
+	result, err := s.scoreClient(ctx, client)
+	if err != nil {
+		return batchOutcome{err: newBatchError(job.clientID, err)}
+	}
+
+	return batchOutcome{result: result}
+}
+
+func newBatchError(clientID int64, err error) *dto.BatchScoringError {
+	code := dto.BatchErrorInternal
+	switch {
+	case errors.Is(err, domainerrors.ErrClientNotFound), errors.Is(err, domainerrors.ErrInvalidClientID):
+		code = dto.BatchErrorNotFound
+	case errors.Is(err, domainerrors.ErrNoFeatures):
+		code = dto.BatchErrorNoFeatures
+	case errors.Is(err, domainerrors.ErrInsufficientData):
+		code = dto.BatchErrorNoData
+	case errors.Is(err, domainerrors.ErrMLServiceUnavailable):
+		code = dto.BatchErrorMLDown
+	case errors.Is(err, domainerrors.ErrMLPredictionFailed):
+		code = dto.BatchErrorMLFailure
+	}
+
+	return &dto.BatchScoringError{
+		ClientID: clientID,
+		Code:     code,
+		Message:  err.Error(),
+	}
+}
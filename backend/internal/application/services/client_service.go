@@ -7,26 +7,33 @@ import (
 	"time"
 
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
 	"gorm.io/datatypes"
 )
 
 type clientService struct {
-	clientRepo interfaces.ClientRepository
-	mlService  interfaces.MLService
-	logger     interfaces.Logger
+	clientRepo    interfaces.ClientRepository
+	mlService     interfaces.MLService
+	cache         *PredictionCache
+	featureSchema *models.FeatureSchema
+	logger        interfaces.Logger
 }
 
 func NewClientService(
 	clientRepo interfaces.ClientRepository,
 	mlService interfaces.MLService,
+	cache *PredictionCache,
+	featureSchema *models.FeatureSchema,
 	logger interfaces.Logger,
 ) interfaces.ClientService {
 	return &clientService{
-		clientRepo: clientRepo,
-		mlService:  mlService,
-		logger:     logger.With("component", "ClientService"),
+		clientRepo:    clientRepo,
+		mlService:     mlService,
+		cache:         cache,
+		featureSchema: featureSchema,
+		logger:        logger.With("component", "ClientService"),
 	}
 }
 
@@ -49,7 +56,7 @@ func (s *clientService) SearchClients(ctx context.Context, params dto.SearchPara
 
 	s.logger.Debug("Searching clients", "params", params)
 
-	clients, err := s.clientRepo.Search(ctx, params)
+	clients, err := s.clientRepo.Search(ctx, params, 0)
 	if err != nil {
 		s.logger.Error("Failed to search clients", "error", err)
 		return nil, fmt.Errorf("failed to search clients: %w", err)
@@ -65,10 +72,15 @@ func (s *clientService) CreateClient(ctx context.Context, req *dto.CreateClientR
 
 	s.logger.Debug("Creating client", "first_name", req.FirstName, "last_name", req.LastName)
 
+	if err := validateFeatures(s.featureSchema, req.Features); err != nil {
+		s.logger.Warn("Client features validation failed", "error", err)
+		return nil, err
+	}
+
 	client, err := req.ToModel()
 	if err != nil {
 		s.logger.Error("Failed to convert DTO to model", "error", err)
-		return nil, fmt.Errorf("failed to convert DTO to model: %w", err)
+		return nil, fmt.Errorf("failed to convert DTO to model: %w: %w", domainerrors.ErrInvalidFormat, err)
 	}
 
 	if err := s.validateClient(client); err != nil {
@@ -92,6 +104,11 @@ func (s *clientService) UpdateClient(ctx context.Context, id int64, req *dto.Upd
 
 	s.logger.Debug("Updating client", "id", id)
 
+	if err := validateFeatures(s.featureSchema, req.Features); err != nil {
+		s.logger.Warn("Client features validation failed", "id", id, "error", err)
+		return nil, err
+	}
+
 	client, err := s.clientRepo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("Failed to get client for update", "id", id, "error", err)
@@ -111,7 +128,7 @@ func (s *clientService) UpdateClient(ctx context.Context, id int64, req *dto.Upd
 		parsedDate, err := time.Parse(dto.DateFormat, req.BirthDate)
 		if err != nil {
 			s.logger.Warn("Invalid birth date format", "input", req.BirthDate, "error", err)
-			return nil, fmt.Errorf("invalid birth date format (expected %s): %w", dto.DateFormat, err)
+			return nil, fmt.Errorf("%w: invalid birth date format (expected %s): %w", domainerrors.ErrInvalidFormat, dto.DateFormat, err)
 		}
 		client.BirthDate = parsedDate
 	}
@@ -132,6 +149,9 @@ func (s *clientService) UpdateClient(ctx context.Context, id int64, req *dto.Upd
 		s.logger.Error("Failed to update client", "id", id, "error", err)
 		return nil, fmt.Errorf("failed to update client: %w", err)
 	}
+	if req.Features != nil {
+		s.cache.InvalidateClient(id)
+	}
 
 	s.logger.Info("Client updated successfully", "id", id)
 	return client, nil
@@ -144,6 +164,7 @@ func (s *clientService) DeleteClient(ctx context.Context, id int64) error {
 		s.logger.Error("Failed to delete client", "id", id, "error", err)
 		return fmt.Errorf("failed to delete client: %w", err)
 	}
+	s.cache.InvalidateClient(id)
 
 	s.logger.Info("Client deleted successfully", "id", id)
 	return nil
@@ -163,7 +184,7 @@ func (s *clientService) ListClients(ctx context.Context, limit, offset int) ([]m
 
 func (s *clientService) validateClient(client *models.Client) error {
 	if !client.IsValid() {
-		return fmt.Errorf("client has invalid fields")
+		return fmt.Errorf("%w: client has invalid fields", domainerrors.ErrInvalidInput)
 	}
 	return nil
 }
@@ -3,16 +3,21 @@ package services
 import (
 	"math"
 
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
 )
 
-type CreditLimitCalculator struct{}
+type CreditLimitCalculator struct {
+	policy config.CreditPolicyConfig
+}
 
-func NewCreditLimitCalculator() *CreditLimitCalculator {
-	return &CreditLimitCalculator{}
+func NewCreditLimitCalculator(policy config.CreditPolicyConfig) *CreditLimitCalculator {
+	return &CreditLimitCalculator{policy: policy}
 }
 
 func (c *CreditLimitCalculator) Calculate(input dto.CreditLimitInput) dto.CreditLimitResult {
+	p := c.policy
+
 	I := input.PredictedIncome
 	C := input.ActiveCCMaxLimit
 	O := input.OutstandSum
@@ -20,23 +25,21 @@ func (c *CreditLimitCalculator) Calculate(input dto.CreditLimitInput) dto.Credit
 	black := input.BlacklistFlag
 	avgMonthlyPayment := input.TurnCurrentCreditAvgV2
 
-	PExist := math.Max(O/36.0, 0.0)
+	PExist := math.Max(O/float64(p.AmortizationMonths), 0.0)
 
-	PDNReg := 0.50
-	PDNBank := 0.40
-
-	var denom float64
+	denom := p.FallbackDenominator
+	denomSource := dto.DenominatorFallback
 	if C > 0 {
-		denom = avgMonthlyPayment / C
-		if math.IsInf(denom, 0) || math.IsNaN(denom) {
-			denom = 0.15
+		ratio := avgMonthlyPayment / C
+		// нулевая доля платежа дала бы бесконечный лимит
+		if ratio > 0 && !math.IsInf(ratio, 0) && !math.IsNaN(ratio) {
+			denom = ratio
+			denomSource = dto.DenominatorTurnover
 		}
-	} else {
-		denom = 0.15
 	}
 
-	MLegal := math.Max(PDNReg*I-PExist, 0.0)
-	MBank := math.Max(PDNBank*I-PExist, 0.0)
+	MLegal := math.Max(p.LegalPDN*I-PExist, 0.0)
+	MBank := math.Max(p.BankPDN*I-PExist, 0.0)
 
 	addLegal := math.Max(MLegal/denom, 0.0)
 	addBank := math.Max(MBank/denom, 0.0)
@@ -44,7 +47,34 @@ func (c *CreditLimitCalculator) Calculate(input dto.CreditLimitInput) dto.Credit
 	LLegal := C + addLegal
 	LBank := C + addBank
 
-	if black == 1 || ovrd > 0 {
+	breakdown := &dto.CreditLimitBreakdown{
+		PolicyVersion:        p.Version,
+		PredictedIncome:      I,
+		ActiveCCMaxLimit:     C,
+		OutstandSum:          O,
+		AmortizationMonths:   p.AmortizationMonths,
+		ExistingPayment:      PExist,
+		LegalPDN:             p.LegalPDN,
+		BankPDN:              p.BankPDN,
+		LegalMonthlyHeadroom: MLegal,
+		BankMonthlyHeadroom:  MBank,
+		Denominator:          denom,
+		DenominatorSource:    denomSource,
+		LegalLimitBeforeRule: LLegal,
+		BankLimitBeforeRule:  LBank,
+		OverdueSum:           ovrd,
+		BlacklistFlag:        black,
+		ZeroedReasons:        []string{},
+	}
+
+	if black == 1 {
+		breakdown.ZeroedReasons = append(breakdown.ZeroedReasons, dto.ZeroReasonBlacklist)
+	}
+	if ovrd > p.MaxOverdueSum {
+		breakdown.ZeroedReasons = append(breakdown.ZeroedReasons, dto.ZeroReasonOverdue)
+	}
+	if len(breakdown.ZeroedReasons) > 0 {
+		breakdown.Zeroed = true
 		LLegal = 0.0
 		LBank = 0.0
 	}
@@ -52,21 +82,7 @@ func (c *CreditLimitCalculator) Calculate(input dto.CreditLimitInput) dto.Credit
 	return dto.CreditLimitResult{
 		LimitLegal:                LLegal,
 		RecommendationCreditLimit: LBank,
+		PolicyVersion:             p.Version,
+		Breakdown:                 breakdown,
 	}
 }
-
-func formatFactors(factors map[string]float64) []string {
-	result := make([]string, 0, len(factors))
-	for name := range factors {
-		result = append(result, name)
-	}
-	return result
-}
-
-func FormatPositiveFactors(factors map[string]float64) []string {
-	return formatFactors(factors)
-}
-
-func FormatNegativeFactors(factors map[string]float64) []string {
-	return formatFactors(factors)
-}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// ClientAgeVariable переменная правил с полным возрастом клиента по дате рождения
+const ClientAgeVariable = "client_age"
+
+// DeclineRuleEngine проверяет правила жесткого отказа до расчета лимита
+type DeclineRuleEngine struct {
+	rules []config.DeclineRuleConfig
+}
+
+func NewDeclineRuleEngine(rules []config.DeclineRuleConfig) *DeclineRuleEngine {
+	return &DeclineRuleEngine{rules: rules}
+}
+
+// Evaluate возвращает решение и все сработавшие правила. Правило по признаку
+// с нечисловым значением не срабатывает.
+func (e *DeclineRuleEngine) Evaluate(client *models.Client, features map[string]interface{}, now time.Time) (string, []dto.DeclineReason) {
+	reasons := []dto.DeclineReason{}
+	for _, rule := range e.rules {
+		value, ok := ruleValue(rule.Feature, client, features, now)
+		if !ok || !compare(value, rule.Operator, rule.Value) {
+			continue
+		}
+		reasons = append(reasons, dto.DeclineReason{
+			Code:    rule.Code,
+			Message: rule.Message,
+		})
+	}
+
+	if len(reasons) > 0 {
+		return dto.DecisionDeclined, reasons
+	}
+	return dto.DecisionApproved, reasons
+}
+
+func ruleValue(name string, client *models.Client, features map[string]interface{}, now time.Time) (float64, bool) {
+	if name == ClientAgeVariable {
+		if client.BirthDate.IsZero() {
+			return 0, false
+		}
+		return float64(dto.AgeAt(client.BirthDate, now)), true
+	}
+
+	switch v := features[name].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func compare(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case "gt":
+		return value > threshold
+	case "gte":
+		return value >= threshold
+	case "lt":
+		return value < threshold
+	case "lte":
+		return value <= threshold
+	case "eq":
+		return value == threshold
+	case "ne":
+		return value != threshold
+	}
+	return false
+}
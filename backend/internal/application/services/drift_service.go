@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// psiEpsilon доля, которой заменяется пустой бин, чтобы PSI оставался конечным
+const psiEpsilon = 1e-4
+
+type driftService struct {
+	scoringRepo   interfaces.ScoringRepository
+	driftRepo     interfaces.DriftRepository
+	featureSchema *models.FeatureSchema
+	cfg           config.DriftConfig
+	logger        interfaces.Logger
+}
+
+func NewDriftService(
+	scoringRepo interfaces.ScoringRepository,
+	driftRepo interfaces.DriftRepository,
+	featureSchema *models.FeatureSchema,
+	cfg config.DriftConfig,
+	logger interfaces.Logger,
+) interfaces.DriftService {
+	return &driftService{
+		scoringRepo:   scoringRepo,
+		driftRepo:     driftRepo,
+		featureSchema: featureSchema,
+		cfg:           cfg,
+		logger:        logger.With("component", "DriftService"),
+	}
+}
+
+// BuildReference строит эталонные распределения числовых признаков схемы
+// и прогнозов по скорингам за [from, to) и заменяет ими прежний эталон
+func (s *driftService) BuildReference(ctx context.Context, from, to time.Time) (*dto.DriftReferenceResponse, error) {
+	sample, err := s.loadSample(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := s.numericFeatures()
+	references := make([]models.DriftReference, 0, len(names)+1)
+	for _, name := range append(names, models.PredictionDriftName) {
+		values, missing := sample.values(name)
+		edges := quantileEdges(values, s.cfg.Bins)
+
+		edgesJSON, err := json.Marshal(edges)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal drift edges: %w", err)
+		}
+		proportionsJSON, err := json.Marshal(binProportions(values, edges))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal drift proportions: %w", err)
+		}
+
+		references = append(references, models.DriftReference{
+			Name:        name,
+			Edges:       edgesJSON,
+			Proportions: proportionsJSON,
+			MissingRate: float64(missing) / float64(sample.size()),
+			SampleSize:  sample.size(),
+			WindowFrom:  from,
+			WindowTo:    to,
+		})
+	}
+
+	if err := s.driftRepo.ReplaceReferences(ctx, references); err != nil {
+		return nil, fmt.Errorf("failed to save drift references: %w", err)
+	}
+
+	s.logger.Info("Drift reference built", "sample_size", sample.size(), "features", len(names))
+	return &dto.DriftReferenceResponse{
+		WindowFrom: from,
+		WindowTo:   to,
+		SampleSize: sample.size(),
+		Features:   len(names),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// GetDrift сравнивает скоринги за последние cfg.WindowDays дней с эталоном.
+// Признаки, превысившие пороги, пишутся в лог как алерты.
+func (s *driftService) GetDrift(ctx context.Context) (*dto.DriftReport, error) {
+	references, err := s.driftRepo.ListReferences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drift references: %w", err)
+	}
+	if len(references) == 0 {
+		return nil, domainerrors.ErrDriftReferenceNotFound
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -s.cfg.WindowDays)
+	sample, err := s.loadSample(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	first := references[0]
+	report := &dto.DriftReport{
+		WindowFrom: from,
+		WindowTo:   to,
+		SampleSize: sample.size(),
+		Reference: dto.DriftReferenceResponse{
+			WindowFrom: first.WindowFrom,
+			WindowTo:   first.WindowTo,
+			SampleSize: first.SampleSize,
+			Features:   len(references) - 1,
+			CreatedAt:  first.CreatedAt,
+		},
+		Thresholds: dto.DriftThresholds{PSI: s.cfg.PSIAlert, MissingRate: s.cfg.MissingRateAlert},
+		Features:   make([]dto.DriftItem, 0, len(references)),
+	}
+
+	for i := range references {
+		item, err := s.compare(&references[i], sample)
+		if err != nil {
+			return nil, err
+		}
+		if item.Alert {
+			report.Alerts++
+			s.logger.Warn("Drift alert",
+				"name", item.Name,
+				"psi", item.PSI,
+				"missing_rate_delta", item.MissingRateDelta,
+				"reasons", item.AlertReasons,
+			)
+		}
+
+		if references[i].Name == models.PredictionDriftName {
+			report.Prediction = item
+		} else {
+			report.Features = append(report.Features, item)
+		}
+	}
+
+	sort.SliceStable(report.Features, func(i, j int) bool {
+		return report.Features[i].PSI > report.Features[j].PSI
+	})
+
+	s.logger.Info("Drift computed", "sample_size", sample.size(), "alerts", report.Alerts)
+	return report, nil
+}
+
+func (s *driftService) compare(reference *models.DriftReference, sample *driftSample) (dto.DriftItem, error) {
+	var edges, expected []float64
+	if err := json.Unmarshal(reference.Edges, &edges); err != nil {
+		return dto.DriftItem{}, fmt.Errorf("invalid drift edges of %s: %w", reference.Name, err)
+	}
+	if err := json.Unmarshal(reference.Proportions, &expected); err != nil {
+		return dto.DriftItem{}, fmt.Errorf("invalid drift proportions of %s: %w", reference.Name, err)
+	}
+
+	values, missing := sample.values(reference.Name)
+	missingRate := float64(missing) / float64(sample.size())
+	item := dto.DriftItem{
+		Name:                 reference.Name,
+		PSI:                  roundRatio(psi(expected, binProportions(values, edges))),
+		ReferenceMissingRate: roundRatio(reference.MissingRate),
+		CurrentMissingRate:   roundRatio(missingRate),
+		MissingRateDelta:     roundRatio(missingRate - reference.MissingRate),
+	}
+
+	if item.PSI > s.cfg.PSIAlert {
+		item.AlertReasons = append(item.AlertReasons, dto.DriftAlertPSI)
+	}
+	if math.Abs(item.MissingRateDelta) > s.cfg.MissingRateAlert {
+		item.AlertReasons = append(item.AlertReasons, dto.DriftAlertMissingRate)
+	}
+	item.Alert = len(item.AlertReasons) > 0
+	return item, nil
+}
+
+func (s *driftService) numericFeatures() []string {
+	var names []string
+	for _, name := range s.featureSchema.Names() {
+		if def, ok := s.featureSchema.Get(name); ok && def.Type == models.FeatureNumeric {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (s *driftService) loadSample(ctx context.Context, from, to time.Time) (*driftSample, error) {
+	records, err := s.scoringRepo.ListSnapshots(ctx, from, to, s.cfg.MaxSample)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scoring snapshots: %w", err)
+	}
+
+	sample := &driftSample{}
+	for i := range records {
+		row, err := newDriftRow(&records[i])
+		if err != nil {
+			s.logger.Warn("Skipping scoring snapshot", "scoring_id", records[i].ID, "error", err)
+			continue
+		}
+		sample.rows = append(sample.rows, row)
+	}
+
+	if sample.size() == 0 {
+		return nil, fmt.Errorf("%w: %s - %s", domainerrors.ErrNoScoringSnapshots,
+			from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	return sample, nil
+}
+
+// driftSample признаки и прогнозы сохраненных скорингов. Импутированные
+// значения считаются пропусками и не входят в распределение.
+type driftSample struct {
+	rows []driftRow
+}
+
+type driftRow struct {
+	prediction float64
+	features   map[string]interface{}
+	imputed    map[string]struct{}
+}
+
+func newDriftRow(record *models.ScoringRecord) (driftRow, error) {
+	row := driftRow{prediction: record.PredictIncome, imputed: make(map[string]struct{})}
+	if len(record.Features) > 0 {
+		if err := json.Unmarshal(record.Features, &row.features); err != nil {
+			return row, fmt.Errorf("invalid features: %w", err)
+		}
+	}
+	if len(record.ImputedFeatures) > 0 {
+		var imputed []string
+		if err := json.Unmarshal(record.ImputedFeatures, &imputed); err != nil {
+			return row, fmt.Errorf("invalid imputed features: %w", err)
+		}
+		for _, name := range imputed {
+			row.imputed[name] = struct{}{}
+		}
+	}
+	return row, nil
+}
+
+func (s *driftSample) size() int {
+	return len(s.rows)
+}
+
+// values возвращает непропущенные значения признака и число пропусков
+func (s *driftSample) values(name string) ([]float64, int) {
+	values := make([]float64, 0, len(s.rows))
+	missing := 0
+	for _, row := range s.rows {
+		if name == models.PredictionDriftName {
+			values = append(values, row.prediction)
+			continue
+		}
+		if _, imputed := row.imputed[name]; imputed {
+			missing++
+			continue
+		}
+		value, ok := driftValue(row.features[name])
+		if !ok {
+			missing++
+			continue
+		}
+		values = append(values, value)
+	}
+	return values, missing
+}
+
+func driftValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// quantileEdges внутренние границы бинов по квантилям, без повторов.
+// Бины: (-inf, e1], (e1, e2], ..., (ek, +inf).
+func quantileEdges(values []float64, bins int) []float64 {
+	if len(values) == 0 {
+		return []float64{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	edges := make([]float64, 0, bins-1)
+	for i := 1; i < bins; i++ {
+		edge := sorted[(len(sorted)-1)*i/bins]
+		if len(edges) == 0 || edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+	// верхняя граница, совпадающая с максимумом, дает пустой последний бин
+	if len(edges) > 0 && edges[len(edges)-1] >= sorted[len(sorted)-1] {
+		edges = edges[:len(edges)-1]
+	}
+	return edges
+}
+
+func binProportions(values []float64, edges []float64) []float64 {
+	counts := make([]float64, len(edges)+1)
+	for _, value := range values {
+		counts[sort.SearchFloat64s(edges, value)]++
+	}
+	if len(values) == 0 {
+		return counts
+	}
+	for i := range counts {
+		counts[i] /= float64(len(values))
+	}
+	return counts
+}
+
+// psi Population Stability Index: sum((actual - expected) * ln(actual / expected))
+func psi(expected, actual []float64) float64 {
+	if len(expected) != len(actual) {
+		return 0
+	}
+	var total float64
+	for i := range expected {
+		e := math.Max(expected[i], psiEpsilon)
+		a := math.Max(actual[i], psiEpsilon)
+		total += (a - e) * math.Log(a/e)
+	}
+	return total
+}
+
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// DriftMonitor периодически пересчитывает дрейф, чтобы алерты попадали
+// в лог без запросов к /api/admin/drift
+type DriftMonitor struct {
+	driftService interfaces.DriftService
+	interval     time.Duration
+	logger       interfaces.Logger
+}
+
+func NewDriftMonitor(driftService interfaces.DriftService, cfg config.DriftConfig, logger interfaces.Logger) *DriftMonitor {
+	return &DriftMonitor{
+		driftService: driftService,
+		interval:     time.Duration(cfg.CheckInterval) * time.Second,
+		logger:       logger.With("component", "DriftMonitor"),
+	}
+}
+
+func (m *DriftMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.logger.Info("Drift monitor started", "interval", m.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Drift monitor stopped")
+			return
+		case <-ticker.C:
+			if _, err := m.driftService.GetDrift(ctx); err != nil && ctx.Err() == nil {
+				m.logger.Warn("Drift check skipped", "error", err)
+			}
+		}
+	}
+}
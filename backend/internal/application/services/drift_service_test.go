@@ -0,0 +1,149 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestQuantileEdges(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		bins   int
+		want   []float64
+	}{
+		{
+			name:   "evenly spread values",
+			values: []float64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			bins:   4,
+			want:   []float64{3, 5, 7},
+		},
+		{
+			name:   "constant feature dedupes all edges away",
+			values: []float64{5, 5, 5, 5, 5},
+			bins:   4,
+			want:   []float64{},
+		},
+		{
+			name:   "repeated values dedupe edges",
+			values: []float64{1, 1, 1, 1, 1, 1, 2, 3},
+			bins:   4,
+			want:   []float64{1},
+		},
+		{
+			name:   "all missing reference",
+			values: nil,
+			bins:   4,
+			want:   []float64{},
+		},
+		{
+			name:   "single bin",
+			values: []float64{1, 2, 3},
+			bins:   1,
+			want:   []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quantileEdges(tt.values, tt.bins)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("quantileEdges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinProportions(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		edges  []float64
+		want   []float64
+	}{
+		{
+			name:   "values equal to an edge fall into the lower bin",
+			values: []float64{3, 5, 7, 8},
+			edges:  []float64{3, 5, 7},
+			want:   []float64{0.25, 0.25, 0.25, 0.25},
+		},
+		{
+			name:   "values just above an edge fall into the upper bin",
+			values: []float64{3.5, 5.5, 7.5, 7.5},
+			edges:  []float64{3, 5, 7},
+			want:   []float64{0, 0.25, 0.25, 0.5},
+		},
+		{
+			name:   "constant feature without edges",
+			values: []float64{5, 5, 5},
+			edges:  []float64{},
+			want:   []float64{1},
+		},
+		{
+			name:   "all missing reference",
+			values: nil,
+			edges:  quantileEdges(nil, 10),
+			want:   []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := binProportions(tt.values, tt.edges)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("binProportions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []float64
+		actual   []float64
+		want     float64
+	}{
+		{
+			name:     "identical distributions",
+			expected: []float64{0.2, 0.3, 0.5},
+			actual:   []float64{0.2, 0.3, 0.5},
+			want:     0,
+		},
+		{
+			name:     "known shift",
+			expected: []float64{0.5, 0.5},
+			actual:   []float64{0.25, 0.75},
+			// -0.25 * ln(0.5) + 0.25 * ln(1.5)
+			want: 0.25*math.Ln2 + 0.25*math.Log(1.5),
+		},
+		{
+			name:     "empty bin is clamped to epsilon",
+			expected: []float64{0.5, 0.5},
+			actual:   []float64{0, 1},
+			want:     (psiEpsilon-0.5)*math.Log(psiEpsilon/0.5) + 0.5*math.Log(2),
+		},
+		{
+			name:     "all missing reference against all missing actual",
+			expected: []float64{0},
+			actual:   []float64{0},
+			want:     0,
+		},
+		{
+			name:     "different bin counts",
+			expected: []float64{0.5, 0.5},
+			actual:   []float64{1},
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := psi(tt.expected, tt.actual)
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("psi() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
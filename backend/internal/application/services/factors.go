@@ -0,0 +1,102 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// buildFactors собирает факторы скоринга из вкладов признаков: подпись из схемы,
+// значение признака у клиента, сортировка по убыванию |вклада| и первые topN
+func buildFactors(contributions map[string]float64, features map[string]interface{}, schema *models.FeatureSchema, topN int) []dto.Factor {
+	factors := make([]dto.Factor, 0, len(contributions))
+	for name, contribution := range contributions {
+		factors = append(factors, dto.Factor{
+			Feature:      name,
+			LabelRu:      schema.Label(name),
+			Value:        features[name],
+			Contribution: contribution,
+		})
+	}
+
+	sort.Slice(factors, func(i, j int) bool {
+		a, b := math.Abs(factors[i].Contribution), math.Abs(factors[j].Contribution)
+		if a != b {
+			return a > b
+		}
+		return factors[i].Feature < factors[j].Feature
+	})
+
+	if topN > 0 && len(factors) > topN {
+		factors = factors[:topN]
+	}
+	return factors
+}
+
+// FactorCategorizer группирует вклады признаков в категории по префиксу имени
+type FactorCategorizer struct {
+	categories []config.FactorCategoryConfig
+	other      string
+}
+
+func NewFactorCategorizer(cfg config.FactorsConfig) *FactorCategorizer {
+	categories := slices.Clone(cfg.Categories)
+	// более длинный префикс проверяется раньше: dp_ils_ точнее dp_
+	sort.SliceStable(categories, func(i, j int) bool {
+		return len(categories[i].Prefix) > len(categories[j].Prefix)
+	})
+	return &FactorCategorizer{categories: categories, other: cfg.OtherCategory}
+}
+
+// Category категория признака; без подходящего префикса - other_category
+func (c *FactorCategorizer) Category(feature string) string {
+	for _, category := range c.categories {
+		if strings.HasPrefix(feature, category.Prefix) {
+			return category.Category
+		}
+	}
+	return c.other
+}
+
+// Aggregate суммирует вклады всех признаков по категориям. Доля считается от
+// суммы модулей вкладов, поэтому доли категорий по модулю в сумме дают 1 только
+// когда внутри категорий нет разнонаправленных вкладов.
+func (c *FactorCategorizer) Aggregate(groups ...map[string]float64) []dto.FactorCategory {
+	index := make(map[string]int)
+	categories := []dto.FactorCategory{}
+	total := 0.0
+	for _, contributions := range groups {
+		for feature, contribution := range contributions {
+			name := c.Category(feature)
+			i, ok := index[name]
+			if !ok {
+				i = len(categories)
+				index[name] = i
+				categories = append(categories, dto.FactorCategory{Category: name})
+			}
+			categories[i].Contribution += contribution
+			categories[i].FeatureCount++
+			total += math.Abs(contribution)
+		}
+	}
+
+	for i := range categories {
+		if total > 0 {
+			categories[i].Share = roundRatio(categories[i].Contribution / total)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := math.Abs(categories[i].Contribution), math.Abs(categories[j].Contribution)
+		if a != b {
+			return a > b
+		}
+		return categories[i].Category < categories[j].Category
+	})
+	return categories
+}
@@ -1,240 +0,0 @@
-package services
-
-var RequiredFeatures = []string{
-	"turn_cur_cr_avg_act_v2",
-	"salary_6to12m_avg",
-	"hdb_bki_total_max_limit",
-	"dp_ils_paymentssum_avg_12m",
-	"hdb_bki_total_cc_max_limit",
-	"incomeValue",
-	"gender",
-	"avg_cur_cr_turn",
-	"turn_cur_cr_avg_v2",
-	"turn_cur_cr_max_v2",
-	"hdb_bki_total_pil_max_limit",
-	"age",
-	"dp_ils_avg_salary_1y",
-	"turn_cur_cr_sum_v2",
-	"by_category__amount__sum__eoperation_type_name__ishodjaschij_bystryj_platezh_sbp",
-	"turn_cur_db_sum_v2",
-	"turn_cur_db_avg_act_v2",
-	"dp_ils_avg_salary_2y",
-	"curr_rur_amt_cm_avg",
-	"turn_cur_db_avg_v2",
-	"by_category__amount__sum__eoperation_type_name__vhodjaschij_bystryj_platezh_sbp",
-	"dp_ils_paymentssum_avg_6m",
-	"avg_cur_db_turn",
-	"hdb_bki_active_cc_max_limit",
-	"incomeValueCategory",
-	"avg_by_category__amount__sum__cashflowcategory_name__vydacha_nalichnyh_v_bankomate",
-	"avg_credit_turn_rur",
-	"dp_ils_salary_ratio_1y3y",
-	"by_category__amount__sum__eoperation_type_name__perevod_po_nomeru_telefona",
-	"turn_cur_cr_7avg_avg_v2",
-	"dp_ils_accpayment_avg_12m",
-	"curbal_usd_amt_cm_avg",
-	"avg_by_category__amount__sum__cashflowcategory_name__supermarkety",
-	"avg_loan_cnt_with_insurance",
-	"avg_by_category__amount__sum__cashflowcategory_name__gipermarkety",
-	"uniV5",
-	"turn_cur_db_max_v2",
-	"avg_by_category__amount__sum__cashflowcategory_name__kafe",
-	"turn_other_db_max_v2",
-	"turn_cur_cr_min_v2",
-	"hdb_bki_other_active_pil_outstanding",
-	"turn_cur_db_min_v2",
-	"hdb_bki_total_products",
-	"per_capita_income_rur_amt",
-	"avg_debet_turn_rur",
-	"hdb_relend_active_max_psk",
-	"dda_rur_amt_curr_v2",
-	"mob_cnt_days",
-	"dp_ils_days_from_last_doc",
-	"avg_6m_money_transactions",
-	"transaction_category_supermarket_percent_cnt_2m",
-	"pil",
-	"hdb_bki_total_max_overdue_sum",
-	"avg_6m_clothing",
-	"avg_by_category__amount__sum__cashflowcategory_name__elektronnye_dengi",
-	"bki_total_auto_cnt",
-	"dp_payoutincomedata_payout_avg_3_month",
-	"hdb_outstand_sum",
-	"avg_3m_money_transactions",
-	"min_balance_rur_amt_6m_af",
-	"transaction_category_supermarket_sum_cnt_m3_4",
-	"dp_payoutincomedata_payout_max_3_month",
-	"hdb_bki_total_ip_max_limit",
-	"hdb_bki_total_cnt",
-	"blacklist_flag",
-	"bki_total_oth_cnt",
-	"dp_payoutincomedata_payout_sum_3_month",
-	"hdb_relend_outstand_sum",
-	"total_rur_amt_cm_avg",
-	"mob_cover_days",
-	"dp_payoutincomedata_payout_max_6_month",
-	"label_Below_50k_share_r1",
-	"turn_fdep_db_sum_v2",
-	"dp_ils_accpayment_avg_6m_current",
-	"transaction_category_cash_percent_amt_2m",
-	"curr_rur_amt_3m_avg",
-	"transaction_category_restaurants_sum_amt_m2",
-	"loan_cnt",
-	"turn_fdep_db_avg_v2",
-	"turn_cur_db_7avg_avg_v2",
-	"bki_total_ip_max_outstand",
-	"amount_by_category_90d__summarur_amt__sum__cashflowcategory_name__vydacha_nalichnyh_v_bankomate",
-	"profit_income_out_rur_amt_12m",
-	"avg_6m_hotels",
-	"hdb_ovrd_sum",
-	"dp_ils_total_seniority",
-	"dp_ils_paymentssum_avg_6m_current",
-	"smsInWavg6m",
-	"avg_fdep_db_turn",
-	"device_iphone_avg",
-	"by_category__amount__sum__eoperation_type_name__platezh_za_mobilnyj_cherez_ps",
-	"avg_balance_rur_amt_1m_af",
-	"curr_rur_amt_cm_avg_period_days_ago_v2",
-	"avg_by_category__amount__sum__cashflowcategory_name__oteli",
-	"hdb_bki_total_ip_cnt",
-	"hdb_bki_active_cc_max_outstand",
-	"hdb_other_outstand_sum",
-	"days_to_last_transaction",
-	"hdb_bki_total_pil_max_overdue",
-	"vert_pil_last_credit_step_screen_view_3m",
-	"acard",
-	"bki_total_il_max_limit",
-	"other_credits_count",
-	"tz_msk_timedelta",
-	"turn_save_db_min_v2",
-	"profit_income_out_rur_amt_9m",
-	"dp_ils_ipkcurrentyear_currentyearpensfactor",
-	"avg_by_category__amount__sum__cashflowcategory_name__odezhda",
-	"cntOnnRinCallAvg6m",
-	"dda_rur_amt_3m_avg",
-	"winback_cnt",
-	"salary_median_in_gex_r1",
-	"dp_payoutincomedata_payout_avg_prev_year",
-	"avg_amount_daily_transactions_90d",
-	"vert_has_app_ru_tinkoff_investing",
-	"transaction_category_supermarket_inc_cnt_2m",
-	"vert_pil_sms_success_3m",
-	"min_balance_rur_amt_1m_af",
-	"dp_ils_max_seniority",
-	"avg_by_category__amount__sum__cashflowcategory_name__set_supermarketov",
-	"label_500k_to_1M_share_r1",
-	"avg_by_category__amount__sum__cashflowcategory_name__zarubezhnye_finansovye_operatsii",
-	"bki_total_products",
-	"avg_6m_all",
-	"dp_ils_avg_simultanious_jobs_5y",
-	"dp_ewb_dismissal_due_contract_violation_by_lb_cnt",
-	"summarur_1m_purch",
-	"diff_avg_cr_db_turn",
-	"dp_ils_cnt_changes_1y",
-	"dp_ils_employeers_cnt_last_month",
-	"dp_payoutincomedata_payout_avg_6_month",
-	"dp_ewb_last_organization",
-	"by_category__amount__sum__eoperation_type_name__perevod_mezhdu_svoimi_schetami",
-	"bki_active_auto_cnt",
-	"turn_other_cr_avg_act_v2",
-	"cntVoiceOutMob6m",
-	"avg_by_category__amount__sum__cashflowcategory_name__puteshestvija",
-	"loanacc_rur_amt_cm_avg",
-	"transaction_category_supermarket_sum_cnt_m2",
-	"transaction_category_supermarket_sum_amt_d15",
-	"avg_fdep_cr_turn",
-	"transaction_category_restaurants_percent_cnt_2m",
-	"bki_total_max_limit",
-	"avg_by_category__amount__sum__cashflowcategory_name__reklama_v_internete",
-	"transaction_category_restaurants_percent_amt_2m",
-	"turn_fdep_db_avg_act_v2",
-	"dp_ils_accpayment_avg_6m",
-	"turn_other_cr_sum_v2",
-	"client_active_flag",
-	"avg_by_category__amount__sum__cashflowcategory_name__produkty",
-	"curr_rur_amt_cm_avg_inc_v2",
-	"nonresident_flag",
-	"avg_by_category__amount__sum__cashflowcategory_name__kosmetika",
-	"vert_has_app_ru_vtb_invest",
-	"dp_ils_avg_salary_3y",
-	"hdb_bki_total_auto_max_limit",
-	"days_after_last_request",
-	"cntRegionTripsWavg1m",
-	"vert_has_app_ru_cian_main",
-	"loanacc_rur_amt_curr_v2",
-	"avg_3m_no_cat",
-	"vert_ghost_close_dpay3_last_days",
-	"vert_has_app_ru_raiffeisennews",
-	"dp_ils_days_ip_share_5y",
-	"avg_by_category__amount__sum__cashflowcategory_name__platezhi_cherez_internet",
-	"hdb_bki_total_micro_max_overdue",
-	"bki_total_active_products",
-	"by_category__amount__sum__eoperation_type_name__perevod_s_karty_na_kartu",
-	"calledCtnOutGroup",
-	"vert_pil_loan_application_success_3m",
-	"vert_pil_fee_discount_change_3m",
-	"businessTelSubs",
-	"profit_income_out_rur_amt_l2m",
-	"avg_3m_healthcare_services",
-	"dp_ils_paymentssum_month_avg",
-	"ovrd_sum",
-	"hdb_bki_total_active_products",
-	"hdb_bki_total_micro_cnt",
-	"hdb_bki_active_pil_cnt",
-	"loan_cur_amt",
-	"mob_total_sessions",
-	"dp_ils_days_multiple_job_share_2y",
-	"hdb_bki_total_cc_max_overdue",
-	"lifetimeComp",
-	"hdb_bki_total_pil_last_days",
-	"amount_by_category_90d__summarur_amt__sum__cashflowcategory_name__elektronnye_dengi",
-	"turn_save_cr_max_v2",
-	"hdb_bki_active_pil_max_limit",
-	"dp_ils_accpayment_avg_3m",
-	"avg_6m_restaurants",
-	"hdb_bki_total_pil_cnt",
-	"transaction_category_fastfood_percent_cnt_2m",
-	"hdb_bki_total_pil_max_del90",
-	"accountsalary_out_flag",
-	"cntBlockWavg6m",
-	"express_rur_amt_cm_avg",
-	"loanacc_rur_amt_cm_avg_inc_v2",
-	"hdb_bki_last_product_days",
-	"dp_ils_days_multiple_job_cnt_5y",
-	"dp_ils_accpayment_month_avg",
-	"cred_dda_rur_amt_3m_avg",
-	"avg_3m_all",
-	"hdb_other_active_max_psk",
-	"hdb_bki_other_active_ip_outstanding",
-	"total_sum",
-	"dp_ils_uniq_companies_1y",
-	"avg_6m_travel",
-	"avg_6m_government_services",
-	"hdb_bki_active_cc_max_overdue",
-	"total_rur_amt_cm_avg_period_days_ago_v2",
-	"label_Above_1M_share_r1",
-	"transaction_category_supermarket_sum_cnt_d15",
-	"max_balance_rur_amt_1m_af",
-	"first_salary_income",
-	"job_simplified",
-	"region",
-}
-
-// EnsureAllFeatures дополняет features до 221 поля, заполняя отсутствующие нулями
-func EnsureAllFeatures(features map[string]interface{}) map[string]interface{} {
-	if features == nil {
-		features = make(map[string]interface{})
-	}
-
-	result := make(map[string]interface{})
-	for k, v := range features {
-		result[k] = v
-	}
-
-	for _, field := range RequiredFeatures {
-		if _, exists := result[field]; !exists {
-			result[field] = 0.0
-		}
-	}
-
-	return result
-}
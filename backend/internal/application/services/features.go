@@ -0,0 +1,29 @@
+package services
+
+import (
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// validateFeatures проверяет признаки по схеме и возвращает ValidationError
+// с полями вида features.<name>
+func validateFeatures(schema *models.FeatureSchema, features map[string]interface{}) error {
+	violations := schema.Validate(features)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	fields := make([]domainerrors.FieldError, 0, len(violations))
+	for _, v := range violations {
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "features." + v.Feature,
+			Rule:    v.Rule,
+			Message: v.Message,
+		})
+	}
+
+	return &domainerrors.ValidationError{
+		Err:    domainerrors.ErrInvalidFeatures,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+// HealthDependency внешняя зависимость, проверяемая в /readyz
+type HealthDependency struct {
+	Name     string
+	Required bool
+	Check    func(ctx context.Context) error
+}
+
+type healthService struct {
+	dependencies []HealthDependency
+	timeout      time.Duration
+	version      string
+	logger       interfaces.Logger
+}
+
+func NewHealthService(
+	dependencies []HealthDependency,
+	timeout time.Duration,
+	version string,
+	logger interfaces.Logger,
+) interfaces.HealthService {
+	return &healthService{
+		dependencies: dependencies,
+		timeout:      timeout,
+		version:      version,
+		logger:       logger.With("component", "HealthService"),
+	}
+}
+
+// Readiness проверяет зависимости параллельно, каждую со своим таймаутом
+func (s *healthService) Readiness(ctx context.Context) *dto.ReadinessResponse {
+	statuses := make([]dto.DependencyStatus, len(s.dependencies))
+
+	var wg sync.WaitGroup
+	for i := range s.dependencies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i] = s.check(ctx, &s.dependencies[i])
+		}(i)
+	}
+	wg.Wait()
+
+	response := &dto.ReadinessResponse{
+		Status:       dto.StatusReady,
+		Version:      s.version,
+		Dependencies: statuses,
+	}
+	for _, status := range statuses {
+		if status.Status == dto.DependencyDown && status.Required {
+			response.Status = dto.StatusNotReady
+		}
+	}
+	return response
+}
+
+func (s *healthService) check(ctx context.Context, dependency *HealthDependency) dto.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := dependency.Check(ctx)
+	latency := time.Since(started)
+
+	status := dto.DependencyStatus{
+		Name:      dependency.Name,
+		Status:    dto.DependencyUp,
+		Required:  dependency.Required,
+		LatencyMs: math.Round(float64(latency.Microseconds())/10) / 100,
+	}
+	if err != nil {
+		status.Status = dto.DependencyDown
+		status.Error = err.Error()
+		s.logger.Warn("Dependency is down", "name", dependency.Name, "latency", latency, "error", err)
+	}
+	return status
+}
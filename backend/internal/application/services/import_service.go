@@ -16,16 +16,18 @@ import (
 )
 
 type ImportService struct {
-	clientRepo interfaces.ClientRepository
-	logger     interfaces.Logger
-	batchSize  int
+	clientRepo    interfaces.ClientRepository
+	featureSchema *models.FeatureSchema
+	logger        interfaces.Logger
+	batchSize     int
 }
 
-func NewImportService(clientRepo interfaces.ClientRepository, logger interfaces.Logger) interfaces.ImportService {
+func NewImportService(clientRepo interfaces.ClientRepository, featureSchema *models.FeatureSchema, logger interfaces.Logger) interfaces.ImportService {
 	return &ImportService{
-		clientRepo: clientRepo,
-		logger:     logger.With("component", "ImportService"),
-		batchSize:  500,
+		clientRepo:    clientRepo,
+		featureSchema: featureSchema,
+		logger:        logger.With("component", "ImportService"),
+		batchSize:     500,
 	}
 }
 
@@ -149,6 +151,9 @@ func (s *ImportService) parseClientFromCSVRow(row map[string]string, headers []s
 	}
 
 	features := s.extractFeatures(row, headers)
+	if err := validateFeatures(s.featureSchema, features); err != nil {
+		return nil, err
+	}
 	if len(features) > 0 {
 		featuresJSON, err := json.Marshal(features)
 		if err != nil {
@@ -193,8 +198,8 @@ func (s *ImportService) extractFeatures(row map[string]string, headers []string)
 
 		value := row[header]
 
+		// Пустые значения не сохраняем: при скоринге их заполнит схема признаков
 		if value == "" || value == "nan" || value == "NaN" || value == "null" || value == "None" {
-			features[header] = 0.0
 			continue
 		}
 
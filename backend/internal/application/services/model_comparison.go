@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+type modelComparisonService struct {
+	scoringRepo interfaces.ScoringRepository
+	shadowRepo  interfaces.ShadowPredictionRepository
+	router      *ModelRouter
+	logger      interfaces.Logger
+}
+
+func NewModelComparisonService(
+	scoringRepo interfaces.ScoringRepository,
+	shadowRepo interfaces.ShadowPredictionRepository,
+	router *ModelRouter,
+	logger interfaces.Logger,
+) interfaces.ModelComparisonService {
+	return &modelComparisonService{
+		scoringRepo: scoringRepo,
+		shadowRepo:  shadowRepo,
+		router:      router,
+		logger:      logger.With("component", "ModelComparisonService"),
+	}
+}
+
+func (s *modelComparisonService) GetComparison(ctx context.Context, from, to time.Time) (*dto.ModelComparisonResponse, error) {
+	traffic, err := s.scoringRepo.VariantStats(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate model variant traffic: %w", err)
+	}
+	comparisons, err := s.shadowRepo.Compare(ctx, from, to, s.router.AgreementTolerance())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare shadow predictions: %w", err)
+	}
+
+	response := &dto.ModelComparisonResponse{
+		From:               from.Format(dto.DateFormat),
+		To:                 to.AddDate(0, 0, -1).Format(dto.DateFormat),
+		AgreementTolerance: s.router.AgreementTolerance(),
+		Variants:           make([]dto.ModelVariantResponse, 0, len(s.router.Variants())),
+		Traffic:            make([]dto.VariantTraffic, 0, len(traffic)),
+		Comparisons:        make([]dto.ShadowComparison, 0, len(comparisons)),
+	}
+
+	for _, variant := range s.router.Variants() {
+		response.Variants = append(response.Variants, dto.ModelVariantResponse{
+			Name:            variant.Name,
+			ModelVersion:    variant.ModelVersion,
+			PipelineVersion: variant.PipelineVersion,
+			Shadow:          variant.Shadow,
+			TrafficShare:    roundRatio(s.router.TrafficShare(variant)),
+		})
+	}
+
+	for _, stat := range traffic {
+		response.Traffic = append(response.Traffic, dto.VariantTraffic{
+			Variant:          stat.Variant,
+			Scorings:         stat.Scorings,
+			AvgPredictIncome: roundMoney(stat.AvgPredictIncome),
+			AvgCreditLimit:   roundMoney(stat.AvgCreditLimit),
+		})
+	}
+
+	for _, stat := range comparisons {
+		response.Comparisons = append(response.Comparisons, dto.ShadowComparison{
+			Champion:          stat.ChampionVariant,
+			Challenger:        stat.ChallengerVariant,
+			Pairs:             stat.Pairs,
+			AgreementRate:     roundRatio(stat.AgreementRate),
+			MeanIncomeDiff:    roundMoney(stat.MeanIncomeDiff),
+			MeanAbsIncomeDiff: roundMoney(stat.MeanAbsIncomeDiff),
+			MeanLimitDiff:     roundMoney(stat.MeanLimitDiff),
+			MeanAbsLimitDiff:  roundMoney(stat.MeanAbsLimitDiff),
+			HigherLimitRate:   roundRatio(stat.HigherLimitRate),
+			LowerLimitRate:    roundRatio(stat.LowerLimitRate),
+		})
+	}
+
+	return response, nil
+}
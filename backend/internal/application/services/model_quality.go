@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// unknownGroup группа для примеров без версии модели, региона или даты рождения
+const unknownGroup = "unknown"
+
+// GetModelQuality сравнивает прогноз дохода с подтвержденным доходом
+// для подтверждений с датой в [from, to)
+func (s *trainingService) GetModelQuality(ctx context.Context, from, to time.Time) (*dto.ModelQualityResponse, error) {
+	outcomes, err := s.trainingRepo.ListOutcomes(ctx, from, to, s.quality.RegionFeature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labeled outcomes: %w", err)
+	}
+
+	var overall qualityAccumulator
+	byModel := newQualityGroups()
+	byIncome := newQualityGroups()
+	byRegion := newQualityGroups()
+	byAge := newQualityGroups()
+
+	for _, outcome := range outcomes {
+		overall.add(outcome)
+		byModel.add(orUnknown(outcome.ModelVersion), outcome)
+		byIncome.add(s.incomeBandLabel(outcome.Target), outcome)
+		byRegion.add(orUnknown(outcome.Region), outcome)
+		byAge.add(s.ageGroupLabel(outcome.BirthDate, outcome.ConfirmedAt), outcome)
+	}
+
+	return &dto.ModelQualityResponse{
+		From:           from.Format(dto.DateFormat),
+		To:             to.AddDate(0, 0, -1).Format(dto.DateFormat),
+		Overall:        overall.metrics(),
+		ByModelVersion: byModel.sorted(nil),
+		ByIncomeBand:   byIncome.sorted(s.incomeBandLabels()),
+		ByRegion:       byRegion.sorted(nil),
+		ByAgeGroup:     byAge.sorted(s.ageGroupLabels()),
+	}, nil
+}
+
+// incomeBandLabel диапазон подтвержденного дохода: "30000-60000", "1000000+"
+func (s *trainingService) incomeBandLabel(income float64) string {
+	bounds := s.quality.IncomeBands
+	index := sort.Search(len(bounds), func(i int) bool { return bounds[i] > income })
+	return s.incomeBandLabels()[index]
+}
+
+func (s *trainingService) incomeBandLabels() []string {
+	bounds := s.quality.IncomeBands
+	labels := make([]string, 0, len(bounds)+1)
+	lower := "0"
+	for _, bound := range bounds {
+		upper := strconv.FormatFloat(bound, 'f', -1, 64)
+		labels = append(labels, lower+"-"+upper)
+		lower = upper
+	}
+	return append(labels, lower+"+")
+}
+
+// ageGroupLabel возрастная группа на дату подтверждения дохода: "<25", "25-35", "65+"
+func (s *trainingService) ageGroupLabel(birthDate *time.Time, at time.Time) string {
+	if birthDate == nil {
+		return unknownGroup
+	}
+	age := dto.AgeAt(*birthDate, at)
+	bounds := s.quality.AgeGroups
+	index := sort.Search(len(bounds), func(i int) bool { return bounds[i] > age })
+	return s.ageGroupLabels()[index]
+}
+
+func (s *trainingService) ageGroupLabels() []string {
+	bounds := s.quality.AgeGroups
+	labels := make([]string, 0, len(bounds)+2)
+	labels = append(labels, "<"+strconv.Itoa(bounds[0]))
+	for i := 1; i < len(bounds); i++ {
+		labels = append(labels, strconv.Itoa(bounds[i-1])+"-"+strconv.Itoa(bounds[i]))
+	}
+	return append(labels, strconv.Itoa(bounds[len(bounds)-1])+"+", unknownGroup)
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownGroup
+	}
+	return value
+}
+
+type qualityAccumulator struct {
+	count       int
+	absError    float64
+	absPctError float64
+	error       float64
+}
+
+func (a *qualityAccumulator) add(outcome models.LabeledOutcome) {
+	diff := outcome.PredictIncome - outcome.Target
+	a.count++
+	a.absError += math.Abs(diff)
+	a.error += diff
+	if outcome.Target > 0 {
+		a.absPctError += math.Abs(diff) / outcome.Target * 100
+	}
+}
+
+func (a *qualityAccumulator) metrics() dto.QualityMetrics {
+	if a.count == 0 {
+		return dto.QualityMetrics{}
+	}
+	n := float64(a.count)
+	return dto.QualityMetrics{
+		Count: a.count,
+		MAE:   roundMoney(a.absError / n),
+		MAPE:  roundMoney(a.absPctError / n),
+		Bias:  roundMoney(a.error / n),
+	}
+}
+
+type qualityGroups map[string]*qualityAccumulator
+
+func newQualityGroups() qualityGroups {
+	return make(qualityGroups)
+}
+
+func (g qualityGroups) add(group string, outcome models.LabeledOutcome) {
+	acc, ok := g[group]
+	if !ok {
+		acc = &qualityAccumulator{}
+		g[group] = acc
+	}
+	acc.add(outcome)
+}
+
+// sorted возвращает непустые группы в порядке order, без него - по алфавиту
+func (g qualityGroups) sorted(order []string) []dto.QualityGroup {
+	if order == nil {
+		for group := range g {
+			order = append(order, group)
+		}
+		sort.Strings(order)
+	}
+
+	result := make([]dto.QualityGroup, 0, len(g))
+	for _, group := range order {
+		if acc, ok := g[group]; ok {
+			result = append(result, dto.QualityGroup{Group: group, QualityMetrics: acc.metrics()})
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// ModelVariant вариант модели и клиент ML-сервиса, который его вызывает
+type ModelVariant struct {
+	Name            string
+	ModelVersion    string
+	PipelineVersion string
+	Weight          int
+	Shadow          bool
+	Service         interfaces.MLService
+}
+
+// ModelRouter распределяет скоринги между вариантами модели champion/challenger.
+// Клиент всегда попадает в один и тот же боевой вариант: бакет считается по
+// хешу client ID с солью, пока не меняются веса и соль.
+type ModelRouter struct {
+	live          []ModelVariant
+	shadows       []ModelVariant
+	totalWeight   int
+	salt          string
+	tolerance     float64
+	shadowTimeout time.Duration
+}
+
+// NewModelRouter без вариантов направляет весь трафик в defaultService
+// под именем models.DefaultModelVariant
+func NewModelRouter(defaultService interfaces.MLService, variants []ModelVariant, cfg config.MLConfig) *ModelRouter {
+	router := &ModelRouter{
+		salt:          cfg.Routing.Salt,
+		tolerance:     cfg.Routing.AgreementTolerance,
+		shadowTimeout: time.Duration(cfg.Routing.ShadowTimeout) * time.Second,
+	}
+
+	for _, variant := range variants {
+		if variant.Shadow {
+			router.shadows = append(router.shadows, variant)
+			continue
+		}
+		if variant.Weight > 0 {
+			router.live = append(router.live, variant)
+			router.totalWeight += variant.Weight
+		}
+	}
+
+	if len(router.live) == 0 {
+		router.live = []ModelVariant{{
+			Name:            models.DefaultModelVariant,
+			ModelVersion:    cfg.ModelVersion,
+			PipelineVersion: cfg.PipelineVersion,
+			Weight:          1,
+			Service:         defaultService,
+		}}
+		router.totalWeight = 1
+	}
+
+	return router
+}
+
+// Route выбирает боевой вариант для клиента. Скоринги без client ID
+// (заявители) идут в champion - первый боевой вариант.
+func (r *ModelRouter) Route(clientID int64) ModelVariant {
+	if clientID <= 0 || len(r.live) == 1 {
+		return r.live[0]
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(r.salt + ":" + strconv.FormatInt(clientID, 10)))
+	bucket := int(hash.Sum64() % uint64(r.totalWeight))
+
+	for _, variant := range r.live {
+		if bucket < variant.Weight {
+			return variant
+		}
+		bucket -= variant.Weight
+	}
+	return r.live[0]
+}
+
+func (r *ModelRouter) Shadows() []ModelVariant {
+	return r.shadows
+}
+
+// Variants боевые и теневые варианты в порядке конфигурации боевых, затем теневых
+func (r *ModelRouter) Variants() []ModelVariant {
+	variants := make([]ModelVariant, 0, len(r.live)+len(r.shadows))
+	variants = append(variants, r.live...)
+	return append(variants, r.shadows...)
+}
+
+// TrafficShare доля трафика боевого варианта, у теневого - 0
+func (r *ModelRouter) TrafficShare(variant ModelVariant) float64 {
+	if variant.Shadow || r.totalWeight == 0 {
+		return 0
+	}
+	return float64(variant.Weight) / float64(r.totalWeight)
+}
+
+func (r *ModelRouter) AgreementTolerance() float64 {
+	return r.tolerance
+}
+
+func (r *ModelRouter) ShadowTimeout() time.Duration {
+	return r.shadowTimeout
+}
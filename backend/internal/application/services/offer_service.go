@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+type offerService struct {
+	scoringService interfaces.ScoringService
+	products       []config.LoanProductConfig
+	policy         config.CreditPolicyConfig
+	logger         interfaces.Logger
+}
+
+func NewOfferService(
+	scoringService interfaces.ScoringService,
+	cfg config.OffersConfig,
+	policy config.CreditPolicyConfig,
+	logger interfaces.Logger,
+) interfaces.OfferService {
+	return &offerService{
+		scoringService: scoringService,
+		products:       cfg.Products,
+		policy:         policy,
+		logger:         logger.With("component", "OfferService"),
+	}
+}
+
+// clientLimits лимиты и доход клиента, относительно которых проверяется запрошенная сумма
+type clientLimits struct {
+	predictIncome   float64
+	creditLimit     float64
+	maxCreditLimit  float64
+	existingPayment float64
+}
+
+func (s *offerService) GetOffers(ctx context.Context, clientID int64, req *dto.OfferRequest) (*dto.OffersResponse, error) {
+	s.logger.Debug("Calculating offers", "client_id", clientID, "amount", req.Amount, "term", req.Term, "product", req.Product)
+
+	if math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) || req.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", domainerrors.ErrInvalidInput)
+	}
+	if req.Term <= 0 || req.Term > dto.MaxOfferTerm {
+		return nil, fmt.Errorf("%w: term must be between 1 and %d months", domainerrors.ErrInvalidInput, dto.MaxOfferTerm)
+	}
+	if req.Product != "" && !s.hasProduct(req.Product) {
+		return nil, fmt.Errorf("%w: unknown product %s", domainerrors.ErrInvalidInput, req.Product)
+	}
+
+	limits, err := s.getLimits(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount > limits.maxCreditLimit {
+		return nil, fmt.Errorf("%w: %.2f is above the maximum limit %.2f",
+			domainerrors.ErrAmountExceedsLimit, req.Amount, limits.maxCreditLimit)
+	}
+
+	offers := make([]dto.LoanOffer, 0, len(s.products))
+	for _, product := range s.products {
+		if req.Product != "" && product.Type != req.Product {
+			continue
+		}
+		rate, ok := findRate(product.Rates, req.Amount, req.Term)
+		if !ok {
+			continue
+		}
+		offers = append(offers, s.buildOffer(product, rate, req, limits))
+	}
+
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("%w: amount %.2f, term %d", domainerrors.ErrNoMatchingRate, req.Amount, req.Term)
+	}
+
+	return &dto.OffersResponse{
+		ClientID:        clientID,
+		Amount:          req.Amount,
+		Term:            req.Term,
+		PredictIncome:   limits.predictIncome,
+		CreditLimit:     limits.creditLimit,
+		MaxCreditLimit:  limits.maxCreditLimit,
+		ExistingPayment: limits.existingPayment,
+		Offers:          offers,
+	}, nil
+}
+
+// getLimits берет лимиты из последнего сохраненного скоринга,
+// а если клиента еще не скорили - считает скоринг заново
+func (s *offerService) getLimits(ctx context.Context, clientID int64) (*clientLimits, error) {
+	latest, err := s.scoringService.GetLatestScoring(ctx, clientID)
+	if err == nil {
+		limits := &clientLimits{
+			predictIncome:  latest.PredictIncome,
+			creditLimit:    latest.CreditLimit,
+			maxCreditLimit: latest.MaxCreditLimit,
+		}
+		if latest.Breakdown != nil {
+			limits.existingPayment = latest.Breakdown.ExistingPayment
+		}
+		return limits, nil
+	}
+	if !errors.Is(err, domainerrors.ErrScoringNotFound) {
+		return nil, fmt.Errorf("failed to get latest scoring: %w", err)
+	}
+
+	s.logger.Debug("No stored scoring, calculating", "client_id", clientID)
+	scoring, err := s.scoringService.CalculateScoring(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate scoring: %w", err)
+	}
+
+	limits := &clientLimits{
+		predictIncome:  scoring.PredictIncome,
+		creditLimit:    scoring.RecommendationCreditLimit,
+		maxCreditLimit: scoring.MaxCreditLimit,
+	}
+	if scoring.Breakdown != nil {
+		limits.existingPayment = scoring.Breakdown.ExistingPayment
+	}
+	return limits, nil
+}
+
+func (s *offerService) buildOffer(product config.LoanProductConfig, rate config.RateConfig, req *dto.OfferRequest, limits *clientLimits) dto.LoanOffer {
+	monthlyPayment, schedule := annuitySchedule(req.Amount, rate.AnnualRate, req.Term)
+
+	totalPayment := 0.0
+	for _, item := range schedule {
+		totalPayment += item.Payment
+	}
+	totalPayment = roundMoney(totalPayment)
+
+	var pdn float64
+	if limits.predictIncome > 0 {
+		pdn = (monthlyPayment + limits.existingPayment) / limits.predictIncome
+	}
+
+	return dto.LoanOffer{
+		Product:               product.Type,
+		Name:                  product.Name,
+		AnnualRate:            rate.AnnualRate,
+		MonthlyPayment:        monthlyPayment,
+		TotalPayment:          totalPayment,
+		TotalInterest:         roundMoney(totalPayment - req.Amount),
+		PDN:                   pdn,
+		PDNExceedsLimit:       limits.predictIncome <= 0 || pdn > s.policy.LegalPDN,
+		AboveRecommendedLimit: req.Amount > limits.creditLimit,
+		Schedule:              schedule,
+	}
+}
+
+func (s *offerService) hasProduct(productType string) bool {
+	for _, product := range s.products {
+		if product.Type == productType {
+			return true
+		}
+	}
+	return false
+}
+
+// findRate возвращает первую строку тарифной сетки, в которую попадают сумма и срок
+func findRate(rates []config.RateConfig, amount float64, term int) (config.RateConfig, bool) {
+	for _, rate := range rates {
+		if amount < rate.MinAmount || (rate.MaxAmount > 0 && amount > rate.MaxAmount) {
+			continue
+		}
+		if term < rate.MinTerm || (rate.MaxTerm > 0 && term > rate.MaxTerm) {
+			continue
+		}
+		return rate, true
+	}
+	return config.RateConfig{}, false
+}
+
+// annuitySchedule строит график аннуитетных платежей с округлением до копеек.
+// Последний платеж корректируется так, чтобы остаток стал нулевым.
+func annuitySchedule(amount, annualRate float64, term int) (float64, []dto.ScheduleItem) {
+	monthlyRate := annualRate / 12
+
+	payment := amount / float64(term)
+	if monthlyRate > 0 {
+		payment = amount * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(term)))
+	}
+	payment = roundMoney(payment)
+
+	schedule := make([]dto.ScheduleItem, 0, term)
+	balance := amount
+	for month := 1; month <= term; month++ {
+		interest := roundMoney(balance * monthlyRate)
+		principal := roundMoney(payment - interest)
+		if month == term || principal > balance {
+			principal = roundMoney(balance)
+		}
+		balance = roundMoney(balance - principal)
+
+		schedule = append(schedule, dto.ScheduleItem{
+			Month:     month,
+			Payment:   roundMoney(principal + interest),
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+	}
+
+	return payment, schedule
+}
+
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnnuitySchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      float64
+		annualRate  float64
+		term        int
+		wantPayment float64
+		wantLast    float64
+	}{
+		{
+			name:        "last payment absorbs rounding",
+			amount:      100000,
+			annualRate:  0.12,
+			term:        12,
+			wantPayment: 8884.88,
+			wantLast:    8884.85,
+		},
+		{
+			name:        "zero rate splits principal evenly",
+			amount:      1000,
+			annualRate:  0,
+			term:        3,
+			wantPayment: 333.33,
+			wantLast:    333.34,
+		},
+		{
+			name:        "single month repays amount with interest",
+			amount:      50000,
+			annualRate:  0.24,
+			term:        1,
+			wantPayment: 51000,
+			wantLast:    51000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, schedule := annuitySchedule(tt.amount, tt.annualRate, tt.term)
+
+			if payment != tt.wantPayment {
+				t.Errorf("payment = %.2f, want %.2f", payment, tt.wantPayment)
+			}
+			if len(schedule) != tt.term {
+				t.Fatalf("schedule has %d items, want %d", len(schedule), tt.term)
+			}
+
+			last := schedule[len(schedule)-1]
+			if last.Payment != tt.wantLast {
+				t.Errorf("last payment = %.2f, want %.2f", last.Payment, tt.wantLast)
+			}
+			if last.Balance != 0 {
+				t.Errorf("final balance = %.2f, want 0", last.Balance)
+			}
+
+			var principal float64
+			for i, item := range schedule {
+				if item.Month != i+1 {
+					t.Errorf("item %d has month %d", i, item.Month)
+				}
+				if tt.annualRate == 0 && item.Interest != 0 {
+					t.Errorf("month %d has interest %.2f at zero rate", item.Month, item.Interest)
+				}
+				principal += item.Principal
+			}
+			if math.Abs(principal-tt.amount) > 0.005 {
+				t.Errorf("principal sum = %.2f, want %.2f", principal, tt.amount)
+			}
+		})
+	}
+}
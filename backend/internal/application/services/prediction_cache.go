@@ -0,0 +1,138 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+)
+
+// PredictionCache кэш ответов ML в памяти процесса. Ключ - отпечаток
+// признаков после импутации и версии модели и пайплайна, поэтому при
+// изменении признаков или версии модели старый прогноз не используется.
+// Записи живут ttl и вытесняются по LRU сверх maxEntries.
+type PredictionCache struct {
+	enabled    bool
+	ttl        time.Duration
+	maxEntries int
+
+	mu       sync.Mutex
+	order    *list.List
+	entries  map[string]*list.Element
+	byClient map[int64]map[string]struct{}
+}
+
+type cachedPrediction struct {
+	key      string
+	clientID int64
+	response *dto.MLScoringResponse
+	scoredAt time.Time
+}
+
+func NewPredictionCache(cfg config.PredictionCacheConfig) *PredictionCache {
+	return &PredictionCache{
+		enabled:    cfg.Enabled,
+		ttl:        time.Duration(cfg.TTL) * time.Second,
+		maxEntries: cfg.MaxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		byClient:   make(map[int64]map[string]struct{}),
+	}
+}
+
+// Key отпечаток признаков и версии модели. encoding/json сортирует ключи
+// map, поэтому одинаковые признаки дают одинаковый отпечаток.
+func (c *PredictionCache) Key(features map[string]interface{}, modelVersion, pipelineVersion string) (string, error) {
+	data, err := json.Marshal(features)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal features: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(modelVersion + "\x00" + pipelineVersion + "\x00"))
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Get возвращает прогноз и время его расчета
+func (c *PredictionCache) Get(key string) (*dto.MLScoringResponse, time.Time, bool) {
+	if !c.enabled {
+		return nil, time.Time{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	entry := element.Value.(*cachedPrediction)
+	if time.Since(entry.scoredAt) > c.ttl {
+		c.remove(element)
+		return nil, time.Time{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.response, entry.scoredAt, true
+}
+
+func (c *PredictionCache) Set(key string, clientID int64, response *dto.MLScoringResponse, scoredAt time.Time) {
+	if !c.enabled {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	entry := &cachedPrediction{key: key, clientID: clientID, response: response, scoredAt: scoredAt}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.byClient[clientID] == nil {
+		c.byClient[clientID] = make(map[string]struct{})
+	}
+	c.byClient[clientID][key] = struct{}{}
+
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// InvalidateClient удаляет прогнозы клиента, например после изменения его признаков
+func (c *PredictionCache) InvalidateClient(clientID int64) {
+	if !c.enabled {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.byClient[clientID] {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+	delete(c.byClient, clientID)
+}
+
+func (c *PredictionCache) remove(element *list.Element) {
+	entry := element.Value.(*cachedPrediction)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+
+	if keys := c.byClient[entry.clientID]; keys != nil {
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.byClient, entry.clientID)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+type promoService struct {
+	promoRepo      interfaces.PromoRepository
+	impressionRepo interfaces.PromoImpressionRepository
+	featureSchema  *models.FeatureSchema
+	cfg            config.PromoConfig
+	logger         interfaces.Logger
+}
+
+func NewPromoService(
+	promoRepo interfaces.PromoRepository,
+	impressionRepo interfaces.PromoImpressionRepository,
+	featureSchema *models.FeatureSchema,
+	cfg config.PromoConfig,
+	logger interfaces.Logger,
+) interfaces.PromoService {
+	return &promoService{
+		promoRepo:      promoRepo,
+		impressionRepo: impressionRepo,
+		featureSchema:  featureSchema,
+		cfg:            cfg,
+		logger:         logger.With("component", "PromoService"),
+	}
+}
+
+func (s *promoService) ListCategories(ctx context.Context) ([]*dto.PromoCategoryResponse, error) {
+	categories, err := s.promoRepo.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promo categories: %w", err)
+	}
+	return dto.FromPromoCategories(categories), nil
+}
+
+func (s *promoService) GetCategory(ctx context.Context, id int64) (*dto.PromoCategoryResponse, error) {
+	category, err := s.promoRepo.GetCategory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo category: %w", err)
+	}
+	return dto.FromPromoCategory(category), nil
+}
+
+func (s *promoService) CreateCategory(ctx context.Context, req *dto.PromoCategoryRequest) (*dto.PromoCategoryResponse, error) {
+	category := req.ToModel()
+	// у новой категории все диапазоны новые
+	for i := range category.Bands {
+		category.Bands[i].ID = 0
+	}
+	if err := validatePromoBands(category.Bands); err != nil {
+		s.logger.Warn("Promo bands validation failed", "code", req.Code, "error", err)
+		return nil, err
+	}
+
+	if _, err := s.promoRepo.GetCategoryByCode(ctx, category.Code); err == nil {
+		return nil, fmt.Errorf("%w: code %s", domainerrors.ErrPromoAlreadyExists, category.Code)
+	} else if !errors.Is(err, domainerrors.ErrPromoNotFound) {
+		return nil, fmt.Errorf("failed to check promo category code: %w", err)
+	}
+
+	if err := s.promoRepo.CreateCategory(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create promo category: %w", err)
+	}
+
+	s.logger.Info("Promo category created", "id", category.ID, "code", category.Code)
+	return dto.FromPromoCategory(category), nil
+}
+
+func (s *promoService) UpdateCategory(ctx context.Context, id int64, req *dto.PromoCategoryRequest) (*dto.PromoCategoryResponse, error) {
+	category := req.ToModel()
+	category.ID = id
+	if err := validatePromoBands(category.Bands); err != nil {
+		s.logger.Warn("Promo bands validation failed", "id", id, "error", err)
+		return nil, err
+	}
+
+	existing, err := s.promoRepo.GetCategoryByCode(ctx, category.Code)
+	if err == nil && existing.ID != id {
+		return nil, fmt.Errorf("%w: code %s", domainerrors.ErrPromoAlreadyExists, category.Code)
+	}
+	if err != nil && !errors.Is(err, domainerrors.ErrPromoNotFound) {
+		return nil, fmt.Errorf("failed to check promo category code: %w", err)
+	}
+
+	current, err := s.promoRepo.GetCategory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo category: %w", err)
+	}
+	if err := validatePromoBandIDs(category.Bands, current.Bands); err != nil {
+		s.logger.Warn("Promo band ids validation failed", "id", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.promoRepo.UpdateCategory(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to update promo category: %w", err)
+	}
+
+	updated, err := s.promoRepo.GetCategory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated promo category: %w", err)
+	}
+
+	s.logger.Info("Promo category updated", "id", id, "code", category.Code)
+	return dto.FromPromoCategory(updated), nil
+}
+
+func (s *promoService) DeleteCategory(ctx context.Context, id int64) error {
+	if err := s.promoRepo.DeleteCategory(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete promo category: %w", err)
+	}
+	s.logger.Info("Promo category deleted", "id", id)
+	return nil
+}
+
+func (s *promoService) ListCampaigns(ctx context.Context) ([]*dto.PromoCampaignResponse, error) {
+	campaigns, err := s.promoRepo.ListCampaigns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promo campaigns: %w", err)
+	}
+	return dto.FromPromoCampaigns(campaigns)
+}
+
+func (s *promoService) GetCampaign(ctx context.Context, id int64) (*dto.PromoCampaignResponse, error) {
+	campaign, err := s.promoRepo.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo campaign: %w", err)
+	}
+	return dto.FromPromoCampaign(campaign)
+}
+
+func (s *promoService) CreateCampaign(ctx context.Context, req *dto.PromoCampaignRequest) (*dto.PromoCampaignResponse, error) {
+	if err := s.validateCampaign(ctx, req); err != nil {
+		s.logger.Warn("Promo campaign validation failed", "name", req.Name, "error", err)
+		return nil, err
+	}
+
+	campaign, err := req.ToModel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert promo campaign: %w: %w", domainerrors.ErrInvalidFormat, err)
+	}
+
+	if err := s.promoRepo.CreateCampaign(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to create promo campaign: %w", err)
+	}
+
+	s.logger.Info("Promo campaign created", "id", campaign.ID, "name", campaign.Name)
+	return dto.FromPromoCampaign(campaign)
+}
+
+func (s *promoService) UpdateCampaign(ctx context.Context, id int64, req *dto.PromoCampaignRequest) (*dto.PromoCampaignResponse, error) {
+	if err := s.validateCampaign(ctx, req); err != nil {
+		s.logger.Warn("Promo campaign validation failed", "id", id, "error", err)
+		return nil, err
+	}
+
+	campaign, err := req.ToModel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert promo campaign: %w: %w", domainerrors.ErrInvalidFormat, err)
+	}
+	campaign.ID = id
+
+	if err := s.promoRepo.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to update promo campaign: %w", err)
+	}
+
+	updated, err := s.promoRepo.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated promo campaign: %w", err)
+	}
+
+	s.logger.Info("Promo campaign updated", "id", id)
+	return dto.FromPromoCampaign(updated)
+}
+
+func (s *promoService) DeleteCampaign(ctx context.Context, id int64) error {
+	if err := s.promoRepo.DeleteCampaign(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete promo campaign: %w", err)
+	}
+	s.logger.Info("Promo campaign deleted", "id", id)
+	return nil
+}
+
+// validateCampaign проверяет даты, лимит выдач, категорию и условия таргетинга.
+// Условия могут ссылаться только на признаки схемы и вычисляемые переменные.
+func (s *promoService) validateCampaign(ctx context.Context, req *dto.PromoCampaignRequest) error {
+	var fields []domainerrors.FieldError
+
+	if _, err := s.promoRepo.GetCategory(ctx, req.CategoryID); err != nil {
+		if !errors.Is(err, domainerrors.ErrPromoNotFound) {
+			return fmt.Errorf("failed to check promo category: %w", err)
+		}
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "category_id",
+			Rule:    "exists",
+			Message: fmt.Sprintf("promo category %d does not exist", req.CategoryID),
+		})
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "ends_at",
+			Rule:    "gtfield",
+			Message: "ends_at must be after starts_at",
+		})
+	}
+	if req.MaxIssued < 0 {
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "max_issued",
+			Rule:    "gte",
+			Message: "max_issued must not be negative",
+		})
+	}
+
+	for i, rule := range req.Targeting {
+		field := fmt.Sprintf("targeting[%d]", i)
+		if err := rule.Validate(); err != nil {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field,
+				Rule:    "invalid",
+				Message: err.Error(),
+			})
+			continue
+		}
+		if !s.isTargetable(rule.Feature) {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".feature",
+				Rule:    "unknown",
+				Message: fmt.Sprintf("feature %s is not in the feature schema", rule.Feature),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &domainerrors.ValidationError{Err: domainerrors.ErrInvalidInput, Fields: fields}
+	}
+	return nil
+}
+
+func (s *promoService) isTargetable(feature string) bool {
+	switch feature {
+	case dto.TargetClientAge, dto.TargetPredictIncome, dto.TargetIncome:
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(feature, "*"); ok {
+		for _, name := range s.featureSchema.Names() {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	_, ok := s.featureSchema.Get(feature)
+	return ok
+}
+
+// validatePromoBands проверяет, что диапазоны дохода внутри категории
+// идут подряд без пересечений и разрывов: max_income одного равен min_income следующего
+func validatePromoBands(bands []models.PromoBand) error {
+	var fields []domainerrors.FieldError
+	if len(bands) == 0 {
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "bands",
+			Rule:    "required",
+			Message: "category must have at least one income band",
+		})
+	}
+
+	order := make([]int, len(bands))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return bands[order[a]].MinIncome < bands[order[b]].MinIncome
+	})
+
+	for pos, i := range order {
+		band := bands[i]
+		field := fmt.Sprintf("bands[%d]", i)
+
+		if strings.TrimSpace(band.Promo) == "" {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".promo",
+				Rule:    "required",
+				Message: "promo is required",
+			})
+		}
+		if band.CTAURL != "" && !isHTTPURL(band.CTAURL) {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".cta_url",
+				Rule:    "url",
+				Message: "cta_url must be an absolute http(s) URL",
+			})
+		}
+		if band.MinIncome < 0 {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".min_income",
+				Rule:    "gte",
+				Message: "min_income must not be negative",
+			})
+		}
+		if band.MaxIncome <= band.MinIncome {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".max_income",
+				Rule:    "gtfield",
+				Message: "max_income must be greater than min_income",
+			})
+		}
+
+		if pos == 0 {
+			continue
+		}
+		prev := bands[order[pos-1]]
+		switch {
+		case band.MinIncome < prev.MaxIncome:
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".min_income",
+				Rule:    "overlap",
+				Message: fmt.Sprintf("band [%d, %d) overlaps with [%d, %d)", band.MinIncome, band.MaxIncome, prev.MinIncome, prev.MaxIncome),
+			})
+		case band.MinIncome > prev.MaxIncome:
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field + ".min_income",
+				Rule:    "gap",
+				Message: fmt.Sprintf("gap between %d and %d is not covered by any band", prev.MaxIncome, band.MinIncome),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &domainerrors.ValidationError{Err: domainerrors.ErrInvalidInput, Fields: fields}
+	}
+	return nil
+}
+
+// validatePromoBandIDs проверяет, что переданные id диапазонов принадлежат
+// категории и не повторяются
+func validatePromoBandIDs(bands, existing []models.PromoBand) error {
+	known := make(map[int64]bool, len(existing))
+	for _, band := range existing {
+		known[band.ID] = true
+	}
+
+	var fields []domainerrors.FieldError
+	seen := make(map[int64]bool, len(bands))
+	for i, band := range bands {
+		if band.ID == 0 {
+			continue
+		}
+		field := fmt.Sprintf("bands[%d].id", i)
+		switch {
+		case !known[band.ID]:
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field,
+				Rule:    "exists",
+				Message: fmt.Sprintf("band %d does not belong to this category", band.ID),
+			})
+		case seen[band.ID]:
+			fields = append(fields, domainerrors.FieldError{
+				Field:   field,
+				Rule:    "unique",
+				Message: fmt.Sprintf("band %d is listed more than once", band.ID),
+			})
+		}
+		seen[band.ID] = true
+	}
+
+	if len(fields) > 0 {
+		return &domainerrors.ValidationError{Err: domainerrors.ErrInvalidInput, Fields: fields}
+	}
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
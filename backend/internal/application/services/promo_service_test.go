@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+func band(min, max int64) models.PromoBand {
+	return models.PromoBand{MinIncome: min, MaxIncome: max, Promo: "promo"}
+}
+
+func bandWithID(id int64) models.PromoBand {
+	return models.PromoBand{ID: id}
+}
+
+// fieldRules переводит ошибку валидации в список "поле:правило"
+func fieldRules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error %v is not a ValidationError", err)
+	}
+	if !errors.Is(err, domainerrors.ErrInvalidInput) {
+		t.Errorf("error %v does not wrap ErrInvalidInput", err)
+	}
+	rules := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		rules = append(rules, field.Field+":"+field.Rule)
+	}
+	return rules
+}
+
+func TestValidatePromoBands(t *testing.T) {
+	tests := []struct {
+		name  string
+		bands []models.PromoBand
+		want  []string
+	}{
+		{
+			name:  "touching bands in order",
+			bands: []models.PromoBand{band(0, 50000), band(50000, 100000)},
+		},
+		{
+			name:  "unordered touching bands",
+			bands: []models.PromoBand{band(100000, 200000), band(0, 50000), band(50000, 100000)},
+		},
+		{
+			name:  "no bands",
+			bands: nil,
+			want:  []string{"bands:required"},
+		},
+		{
+			name:  "overlap",
+			bands: []models.PromoBand{band(0, 60000), band(50000, 100000)},
+			want:  []string{"bands[1].min_income:overlap"},
+		},
+		{
+			name:  "overlap reported on the original index",
+			bands: []models.PromoBand{band(50000, 100000), band(0, 60000)},
+			want:  []string{"bands[0].min_income:overlap"},
+		},
+		{
+			name:  "gap",
+			bands: []models.PromoBand{band(0, 50000), band(60000, 100000)},
+			want:  []string{"bands[1].min_income:gap"},
+		},
+		{
+			name:  "max equal to min",
+			bands: []models.PromoBand{band(50000, 50000)},
+			want:  []string{"bands[0].max_income:gtfield"},
+		},
+		{
+			name:  "max below min",
+			bands: []models.PromoBand{band(0, 50000), band(50000, 40000)},
+			want:  []string{"bands[1].max_income:gtfield"},
+		},
+		{
+			name:  "negative min income",
+			bands: []models.PromoBand{band(-1, 50000)},
+			want:  []string{"bands[0].min_income:gte"},
+		},
+		{
+			name: "missing promo and bad url",
+			bands: []models.PromoBand{
+				{MinIncome: 0, MaxIncome: 50000, CTAURL: "ftp://example.com"},
+			},
+			want: []string{"bands[0].promo:required", "bands[0].cta_url:url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldRules(t, validatePromoBands(tt.bands))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validatePromoBands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePromoBandIDs(t *testing.T) {
+	existing := []models.PromoBand{bandWithID(1), bandWithID(2)}
+
+	tests := []struct {
+		name  string
+		bands []models.PromoBand
+		want  []string
+	}{
+		{
+			name:  "known ids and new bands",
+			bands: []models.PromoBand{bandWithID(2), bandWithID(0), bandWithID(1), bandWithID(0)},
+		},
+		{
+			name:  "duplicate id",
+			bands: []models.PromoBand{bandWithID(1), bandWithID(1)},
+			want:  []string{"bands[1].id:unique"},
+		},
+		{
+			name:  "id from another category",
+			bands: []models.PromoBand{bandWithID(1), bandWithID(7)},
+			want:  []string{"bands[1].id:exists"},
+		},
+		{
+			name:  "repeated foreign id is reported as foreign",
+			bands: []models.PromoBand{bandWithID(7), bandWithID(7)},
+			want:  []string{"bands[0].id:exists", "bands[1].id:exists"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldRules(t, validatePromoBandIDs(tt.bands, existing))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validatePromoBandIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
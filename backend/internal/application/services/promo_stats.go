@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+func (s *promoService) RecordOutcome(ctx context.Context, clientID int64, promoID string, status string) (*dto.PromoImpressionResponse, error) {
+	impression, err := s.impressionRepo.GetLatestImpression(ctx, clientID, promoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo impression: %w", err)
+	}
+
+	now := time.Now()
+	recorded, err := s.impressionRepo.RecordOutcome(ctx, impression.ID, status, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record promo outcome: %w", err)
+	}
+	if !recorded {
+		return nil, fmt.Errorf("%w: impression %d", domainerrors.ErrOutcomeAlreadyRecorded, impression.ID)
+	}
+
+	impression.Status = status
+	impression.RespondedAt = &now
+
+	s.logger.Info("Promo outcome recorded", "client_id", clientID, "promo_id", promoID, "status", status)
+	return dto.FromPromoImpression(impression), nil
+}
+
+func (s *promoService) GetStats(ctx context.Context, from, to time.Time) (*dto.PromoStatsResponse, error) {
+	if !to.After(from) {
+		return nil, &domainerrors.ValidationError{
+			Err: domainerrors.ErrInvalidInput,
+			Fields: []domainerrors.FieldError{{
+				Field:   "to",
+				Rule:    "gtfield",
+				Message: "to must not be before from",
+			}},
+		}
+	}
+
+	rows, err := s.impressionRepo.Stats(ctx, from, to, s.cfg.StatsIncomeBands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo stats: %w", err)
+	}
+
+	return &dto.PromoStatsResponse{
+		From:  from.Format(dto.DateFormat),
+		To:    to.AddDate(0, 0, -1).Format(dto.DateFormat),
+		Items: s.groupStats(rows),
+	}, nil
+}
+
+// groupStats собирает строки (промо, диапазон) в статистику по промо.
+// Строки приходят отсортированными по promo_id и номеру диапазона.
+func (s *promoService) groupStats(rows []models.PromoImpressionStat) []*dto.PromoStats {
+	items := make([]*dto.PromoStats, 0)
+	var current *dto.PromoStats
+	for _, row := range rows {
+		if current == nil || current.PromoID != row.PromoID {
+			current = &dto.PromoStats{
+				PromoID:     row.PromoID,
+				Category:    row.Category,
+				Title:       row.Title,
+				IncomeBands: []dto.PromoIncomeBandStats{},
+			}
+			items = append(items, current)
+		}
+
+		band := s.incomeBand(row.Band)
+		band.Add(row.Impressions, row.Accepted, row.Declined)
+		current.IncomeBands = append(current.IncomeBands, band)
+		current.Add(row.Impressions, row.Accepted, row.Declined)
+	}
+	return items
+}
+
+// incomeBand границы диапазона с номером из width_bucket: 0 - ниже первой границы,
+// len(bounds) - от последней границы и выше
+func (s *promoService) incomeBand(index int) dto.PromoIncomeBandStats {
+	bounds := s.cfg.StatsIncomeBands
+	var band dto.PromoIncomeBandStats
+	if index > 0 && index <= len(bounds) {
+		band.MinIncome = bounds[index-1]
+	}
+	if index < len(bounds) {
+		maxIncome := bounds[index]
+		band.MaxIncome = &maxIncome
+	}
+	return band
+}
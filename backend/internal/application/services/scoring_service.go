@@ -4,32 +4,60 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
 )
 
 type scoringService struct {
-	clientRepo    interfaces.ClientRepository
-	mlService     interfaces.MLService
-	creditCalc    *CreditLimitCalculator
-	promoProvider interfaces.PromoProvider
-	logger        interfaces.Logger
+	clientRepo     interfaces.ClientRepository
+	scoringRepo    interfaces.ScoringRepository
+	router         *ModelRouter
+	shadowRepo     interfaces.ShadowPredictionRepository
+	shadowPool     *ShadowPool
+	cache          *PredictionCache
+	creditCalc     *CreditLimitCalculator
+	declineRules   *DeclineRuleEngine
+	categorizer    *FactorCategorizer
+	promoProvider  interfaces.PromoProvider
+	impressionRepo interfaces.PromoImpressionRepository
+	featureSchema  *models.FeatureSchema
+	cfg            config.ScoringConfig
+	logger         interfaces.Logger
 }
 
 func NewScoringService(
 	clientRepo interfaces.ClientRepository,
-	mlService interfaces.MLService,
+	scoringRepo interfaces.ScoringRepository,
+	router *ModelRouter,
+	shadowRepo interfaces.ShadowPredictionRepository,
+	shadowPool *ShadowPool,
+	cache *PredictionCache,
 	promoProvider interfaces.PromoProvider,
+	impressionRepo interfaces.PromoImpressionRepository,
+	featureSchema *models.FeatureSchema,
+	cfg config.ScoringConfig,
 	logger interfaces.Logger,
 ) interfaces.ScoringService {
 	return &scoringService{
-		clientRepo:    clientRepo,
-		mlService:     mlService,
-		creditCalc:    NewCreditLimitCalculator(),
-		promoProvider: promoProvider,
-		logger:        logger.With("component", "ScoringService"),
+		clientRepo:     clientRepo,
+		scoringRepo:    scoringRepo,
+		router:         router,
+		shadowRepo:     shadowRepo,
+		shadowPool:     shadowPool,
+		cache:          cache,
+		creditCalc:     NewCreditLimitCalculator(cfg.CreditPolicy),
+		declineRules:   NewDeclineRuleEngine(cfg.DeclineRules),
+		categorizer:    NewFactorCategorizer(cfg.Factors),
+		promoProvider:  promoProvider,
+		impressionRepo: impressionRepo,
+		featureSchema:  featureSchema,
+		cfg:            cfg,
+		logger:         logger.With("component", "ScoringService"),
 	}
 }
 
@@ -42,32 +70,129 @@ func (s *scoringService) CalculateScoring(ctx context.Context, id int64) (*dto.S
 		return nil, fmt.Errorf("failed to get client for scoring: %w", err)
 	}
 
+	return s.scoreClient(ctx, client)
+}
+
+// scoringRun промежуточные результаты расчета, которые нужны для сохранения в историю
+type scoringRun struct {
+	features   map[string]interface{}
+	mlResponse *dto.MLScoringResponse
+	imputation *models.Imputation
+	variant    string
+	cached     bool
+	stale      bool
+	// staleScoringID сохраненный скоринг, из которого взят устаревший прогноз
+	staleScoringID int64
+}
+
+// scoreClient прогоняет уже загруженного клиента через ML, расчет лимита и промо
+// и сохраняет результат в историю
+func (s *scoringService) scoreClient(ctx context.Context, client *models.Client) (*dto.ScoringResponse, error) {
 	features, err := s.extractFeatures(client)
 	s.logger.Debug("Extracted features", "features", features)
 	if err != nil {
-		s.logger.Error("Failed to extract features", "client_id", id, "error", err)
+		s.logger.Error("Failed to extract features", "client_id", client.ID, "error", err)
 		return nil, fmt.Errorf("failed to extract features: %w", err)
 	}
 
-	features = EnsureAllFeatures(features)
-	s.logger.Debug("Features after ensuring all 221 fields", "feature_count", len(features))
+	response, run, err := s.evaluate(ctx, client, features, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// устаревший прогноз уже есть в истории, повторное сохранение сбросило бы его
+	// возраст; заново подобранные рекомендации учитываются как показы того скоринга
+	if run.stale {
+		s.recordImpressions(ctx, run.staleScoringID, response)
+	} else {
+		s.saveScoring(ctx, response, run)
+	}
+
+	s.logger.Info("Scoring calculated successfully", "client_id", client.ID, "score", response.PredictIncome)
+	return response, nil
+}
+
+// evaluate считает скоринг по переданному набору признаков: импутация, ML,
+// кредитный лимит, промо и факторы. Ничего не сохраняет; при preview
+// подобранные промо-кампании не учитываются в лимитах выдач.
+func (s *scoringService) evaluate(ctx context.Context, client *models.Client, features map[string]interface{}, preview bool) (*dto.ScoringResponse, *scoringRun, error) {
+	id := client.ID
+	now := time.Now()
+
+	features, imputation := s.featureSchema.Impute(features)
+	completeness := imputation.Completeness()
+	insufficientData := completeness < s.cfg.MinDataCompleteness
+	s.logger.Debug("Features after imputation",
+		"feature_count", len(features),
+		"imputed_count", len(imputation.Imputed),
+		"data_completeness", completeness,
+		"schema_version", s.featureSchema.Version,
+	)
 
-	mlResponse, err := s.mlService.PredictWithExplanation(ctx, features)
+	if insufficientData {
+		s.logger.Warn("Insufficient data for scoring",
+			"client_id", id,
+			"data_completeness", completeness,
+			"threshold", s.cfg.MinDataCompleteness,
+			"missing_important", imputation.MissingImportant,
+		)
+		if s.cfg.RejectInsufficientData {
+			return nil, nil, fmt.Errorf("%w: data completeness %.2f is below %.2f",
+				domainerrors.ErrInsufficientData, completeness, s.cfg.MinDataCompleteness)
+		}
+	}
+
+	decision, declineReasons := s.declineRules.Evaluate(client, features, now)
+	if decision == dto.DecisionDeclined {
+		s.logger.Info("Client declined by rules", "client_id", id, "reasons", declineReasons)
+	}
+
+	variant := s.router.Route(id)
+	variantName := variant.Name
+	mlResponse, scoredAt, cached, err := s.predict(ctx, id, variant, features)
+	var stale *stalePrediction
 	if err != nil {
-		s.logger.Error("Failed to predict scoring", "client_id", id, "error", err)
-		return nil, fmt.Errorf("failed to predict scoring: %w", err)
+		if !preview {
+			stale = s.lastKnownPrediction(ctx, id, features, err)
+		}
+		if stale == nil {
+			s.logger.Error("Failed to predict scoring", "client_id", id, "variant", variant.Name, "error", err)
+			return nil, nil, fmt.Errorf("failed to predict scoring: %w: %w", domainerrors.ErrMLPredictionFailed, err)
+		}
+		mlResponse, scoredAt, variantName = stale.mlResponse, stale.scoredAt, stale.variant
 	}
 
-	creditLimit := s.calculateCreditLimit(features, mlResponse.Prediction)
-	recommendations := s.getRecommendations(ctx, mlResponse.Prediction)
+	// при жестком отказе лимит не считается
+	creditLimit := dto.CreditLimitResult{PolicyVersion: s.cfg.CreditPolicy.Version}
+	if decision == dto.DecisionApproved {
+		creditLimit = s.calculateCreditLimit(features, mlResponse.Prediction)
+	}
 	positiveFactors, negativeFactors := s.splitFactorsBySign(mlResponse.Explanation)
+	topN := s.cfg.Factors.TopN
+	rankedPositive := buildFactors(positiveFactors, features, s.featureSchema, topN)
+	rankedNegative := buildFactors(negativeFactors, features, s.featureSchema, topN)
+	factorCategories := s.categorizer.Aggregate(positiveFactors, negativeFactors)
+	if stale != nil {
+		rankedPositive, rankedNegative = stale.positiveFactors, stale.negativeFactors
+		factorCategories = stale.factorCategories
+	}
 
 	clientDTO, err := dto.FromModel(client)
 	if err != nil {
 		s.logger.Error("Failed to convert client to DTO", "error", err)
-		return nil, fmt.Errorf("failed to convert client to DTO: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert client to DTO: %w", err)
 	}
 
+	recommendations := s.getRecommendations(ctx, &dto.PromoRequest{
+		ClientID:      client.ID,
+		Income:        clientDTO.Income,
+		PredictIncome: mlResponse.Prediction,
+		Age:           dto.AgeAt(client.BirthDate, now),
+		Features:      features,
+		Preview:       preview,
+		Now:           now,
+	})
+
 	response := &dto.ScoringResponse{
 		Id:                        client.ID,
 		FirstName:                 client.FirstName,
@@ -79,12 +204,220 @@ func (s *scoringService) CalculateScoring(ctx context.Context, id int64) (*dto.S
 		RecommendationCreditLimit: creditLimit.RecommendationCreditLimit,
 		MaxCreditLimit:            creditLimit.LimitLegal,
 		Recommendations:           recommendations,
-		PositiveFactors:           FormatPositiveFactors(positiveFactors),
-		NegativeFactors:           FormatNegativeFactors(negativeFactors),
+		PositiveFactors:           rankedPositive,
+		NegativeFactors:           rankedNegative,
+		FactorCategories:          factorCategories,
+		DataCompleteness:          completeness,
+		MissingImportantFeatures:  imputation.MissingImportant,
+		InsufficientData:          insufficientData,
+		Decision:                  decision,
+		DeclineReasons:            declineReasons,
+		PolicyVersion:             creditLimit.PolicyVersion,
+		Breakdown:                 creditLimit.Breakdown,
+		ModelVariant:              variantName,
+		Cached:                    cached,
+		ScoredAt:                  scoredAt,
+	}
+	if stale != nil {
+		response.Stale = true
+		response.StaleAgeSeconds = int64(now.Sub(scoredAt).Seconds())
 	}
 
-	s.logger.Info("Scoring calculated successfully", "client_id", id, "score", mlResponse.Prediction)
-	return response, nil
+	run := &scoringRun{
+		features:   features,
+		mlResponse: mlResponse,
+		imputation: imputation,
+		variant:    variantName,
+		cached:     cached,
+		stale:      stale != nil,
+	}
+	if stale != nil {
+		run.staleScoringID = stale.scoringID
+	}
+	return response, run, nil
+}
+
+// predict берет прогноз варианта модели из кэша или запрашивает его у ML.
+// Прогнозы резервной модели не кэшируются, чтобы после восстановления
+// ML-сервиса клиент сразу получил прогноз основной модели.
+func (s *scoringService) predict(ctx context.Context, clientID int64, variant ModelVariant, features map[string]interface{}) (*dto.MLScoringResponse, time.Time, bool, error) {
+	key, err := s.cache.Key(features, variant.ModelVersion, variant.PipelineVersion)
+	if err != nil {
+		s.logger.Warn("Failed to build prediction cache key", "client_id", clientID, "error", err)
+	}
+	if key != "" {
+		if mlResponse, scoredAt, ok := s.cache.Get(key); ok {
+			s.logger.Debug("Prediction served from cache", "client_id", clientID, "variant", variant.Name)
+			return mlResponse, scoredAt, true, nil
+		}
+	}
+
+	mlResponse, err := variant.Service.PredictWithExplanation(ctx, features)
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+
+	scoredAt := time.Now()
+	if key != "" && !mlResponse.Fallback {
+		s.cache.Set(key, clientID, mlResponse, scoredAt)
+	}
+	return mlResponse, scoredAt, false, nil
+}
+
+func (s *scoringService) GetScoringHistory(ctx context.Context, clientID int64, limit, offset int) (*dto.ScoringHistoryResponse, error) {
+	s.logger.Debug("Getting scoring history", "client_id", clientID, "limit", limit, "offset", offset)
+
+	if _, err := s.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	records, total, err := s.scoringRepo.ListByClientID(ctx, clientID, limit, offset)
+	if err != nil {
+		s.logger.Error("Failed to list scoring history", "client_id", clientID, "error", err)
+		return nil, fmt.Errorf("failed to list scoring history: %w", err)
+	}
+
+	items, err := dto.FromScoringRecords(records)
+	if err != nil {
+		s.logger.Error("Failed to convert scoring records to DTO", "client_id", clientID, "error", err)
+		return nil, fmt.Errorf("failed to convert scoring records: %w", err)
+	}
+
+	return &dto.ScoringHistoryResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (s *scoringService) GetLatestScoring(ctx context.Context, clientID int64) (*dto.ScoringRecordResponse, error) {
+	s.logger.Debug("Getting latest scoring", "client_id", clientID)
+
+	if _, err := s.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	record, err := s.scoringRepo.GetLatestByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest scoring: %w", err)
+	}
+
+	return dto.FromScoringRecord(record)
+}
+
+// saveScoring сохраняет результат скоринга в историю. Ошибка сохранения
+// не должна ломать ответ аналитику, поэтому только логируется.
+func (s *scoringService) saveScoring(ctx context.Context, response *dto.ScoringResponse, run *scoringRun) {
+	record, err := buildScoringRecord(response, run)
+	if err != nil {
+		s.logger.Error("Failed to build scoring record", "client_id", response.Id, "error", err)
+		return
+	}
+
+	if err := s.scoringRepo.Create(ctx, record); err != nil {
+		s.logger.Error("Failed to save scoring result", "client_id", response.Id, "error", err)
+		return
+	}
+
+	s.recordImpressions(ctx, record.ID, response)
+	s.runShadows(record, run)
+}
+
+// recordImpressions сохраняет показ каждой рекомендации сохраненного скоринга
+func (s *scoringService) recordImpressions(ctx context.Context, scoringID int64, response *dto.ScoringResponse) {
+	if len(response.Recommendations) == 0 {
+		return
+	}
+
+	impressions := make([]models.PromoImpression, 0, len(response.Recommendations))
+	for _, recommendation := range response.Recommendations {
+		impressions = append(impressions, models.PromoImpression{
+			ClientID:      response.Id,
+			ScoringID:     scoringID,
+			PromoID:       recommendation.ID,
+			Category:      recommendation.Category,
+			Title:         recommendation.Title,
+			PredictIncome: response.PredictIncome,
+			Status:        models.ImpressionShown,
+		})
+	}
+
+	if err := s.impressionRepo.CreateImpressions(ctx, impressions); err != nil {
+		s.logger.Error("Failed to save promo impressions", "client_id", response.Id, "scoring_id", scoringID, "error", err)
+	}
+}
+
+func buildScoringRecord(response *dto.ScoringResponse, run *scoringRun) (*models.ScoringRecord, error) {
+	mlResponse, imputation := run.mlResponse, run.imputation
+
+	recommendations, err := json.Marshal(response.Recommendations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recommendations: %w", err)
+	}
+	positiveFactors, err := json.Marshal(response.PositiveFactors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal positive factors: %w", err)
+	}
+	negativeFactors, err := json.Marshal(response.NegativeFactors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal negative factors: %w", err)
+	}
+	factorCategories, err := json.Marshal(response.FactorCategories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal factor categories: %w", err)
+	}
+	imputedFeatures, err := json.Marshal(imputation.Imputed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal imputed features: %w", err)
+	}
+	missingImportant, err := json.Marshal(imputation.MissingImportant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal missing important features: %w", err)
+	}
+	declineReasons, err := json.Marshal(response.DeclineReasons)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal decline reasons: %w", err)
+	}
+	breakdown, err := json.Marshal(response.Breakdown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credit limit breakdown: %w", err)
+	}
+	features, err := json.Marshal(run.features)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal features: %w", err)
+	}
+	scoredAt := response.ScoredAt
+
+	return &models.ScoringRecord{
+		ClientID:        response.Id,
+		PredictIncome:   response.PredictIncome,
+		CreditLimit:     response.RecommendationCreditLimit,
+		MaxCreditLimit:  response.MaxCreditLimit,
+		Recommendations: recommendations,
+		PositiveFactors: positiveFactors,
+		NegativeFactors: negativeFactors,
+		ModelVersion:    mlResponse.ModelVersion,
+		PipelineVersion: mlResponse.PipelineVersion,
+		MLUID:           mlResponse.ID,
+		ModelVariant:    run.variant,
+
+		FactorCategories: factorCategories,
+
+		DataCompleteness:         response.DataCompleteness,
+		InsufficientData:         response.InsufficientData,
+		ImputedFeatures:          imputedFeatures,
+		MissingImportantFeatures: missingImportant,
+
+		Decision:       response.Decision,
+		DeclineReasons: declineReasons,
+
+		PolicyVersion:   response.PolicyVersion,
+		CreditBreakdown: breakdown,
+
+		Features: features,
+		ScoredAt: &scoredAt,
+	}, nil
 }
 
 func (s *scoringService) calculateCreditLimit(features map[string]interface{}, predictedIncome float64) dto.CreditLimitResult {
@@ -92,12 +425,11 @@ func (s *scoringService) calculateCreditLimit(features map[string]interface{}, p
 	return s.creditCalc.Calculate(creditLimitInput)
 }
 
-func (s *scoringService) getRecommendations(ctx context.Context, prediction float64) []string {
-	predictedIncome := int64(prediction)
-	recommendations, err := s.promoProvider.GetPromos(ctx, predictedIncome, prediction)
+func (s *scoringService) getRecommendations(ctx context.Context, req *dto.PromoRequest) []dto.Recommendation {
+	recommendations, err := s.promoProvider.GetRecommendations(ctx, req)
 	if err != nil {
-		s.logger.Error("Failed to get promos", "error", err)
-		return []string{}
+		s.logger.Error("Failed to get recommendations", "error", err)
+		return []dto.Recommendation{}
 	}
 	return recommendations
 }
@@ -171,7 +503,7 @@ func (s *scoringService) extractFeatures(client *models.Client) (map[string]inte
 	}
 
 	if len(features) == 0 {
-		return nil, fmt.Errorf("no features available for client %d", client.ID)
+		return nil, fmt.Errorf("%w: client %d", domainerrors.ErrNoFeatures, client.ID)
 	}
 
 	return features, nil
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+// ShadowPool ограниченный пул фоновых вызовов теневых вариантов. Задачи сверх
+// очереди отбрасываются: теневой прогноз нужен только для сравнения и не должен
+// копить горутины под нагрузкой пакетного скоринга.
+type ShadowPool struct {
+	tasks  chan func(ctx context.Context)
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	logger interfaces.Logger
+}
+
+// NewShadowPool запускает cfg.ShadowWorkers обработчиков с очередью cfg.ShadowQueueSize
+func NewShadowPool(cfg config.RoutingConfig, logger interfaces.Logger) *ShadowPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &ShadowPool{
+		tasks:  make(chan func(ctx context.Context), cfg.ShadowQueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		logger: logger.With("component", "ShadowPool"),
+	}
+
+	pool.wg.Add(cfg.ShadowWorkers)
+	for i := 0; i < cfg.ShadowWorkers; i++ {
+		go pool.worker()
+	}
+	return pool
+}
+
+func (p *ShadowPool) worker() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		// после остановки оставшиеся задачи только вычитываются из очереди
+		if p.ctx.Err() != nil {
+			continue
+		}
+		task(p.ctx)
+	}
+}
+
+// Submit ставит задачу в очередь без ожидания. false - очередь заполнена
+// или пул остановлен, задача отброшена.
+func (p *ShadowPool) Submit(task func(ctx context.Context)) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close прерывает выполняемые вызовы, отбрасывает очередь и ждет обработчики
+func (p *ShadowPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.tasks)
+	p.mu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+	p.logger.Info("Shadow pool stopped")
+}
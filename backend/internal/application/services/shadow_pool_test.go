@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces/mocks"
+)
+
+func TestShadowPoolDropsOnOverflow(t *testing.T) {
+	pool := NewShadowPool(config.RoutingConfig{ShadowWorkers: 1, ShadowQueueSize: 1}, &mocks.MockLogger{})
+
+	started := make(chan struct{})
+	if !pool.Submit(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	}) {
+		t.Fatal("first task rejected")
+	}
+	<-started
+
+	if !pool.Submit(func(context.Context) {}) {
+		t.Fatal("queued task rejected")
+	}
+	if pool.Submit(func(context.Context) {}) {
+		t.Fatal("task accepted over queue capacity")
+	}
+
+	// Close прерывает выполняемую задачу и не зависает на очереди
+	pool.Close()
+
+	if pool.Submit(func(context.Context) {}) {
+		t.Fatal("task accepted after close")
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// runShadows в фоне считает сохраненный скоринг теневыми вариантами модели
+// с теми же признаками и политикой лимита. Результат только сохраняется
+// для сравнения и на ответ клиенту не влияет. Для прогноза из кэша теневые
+// варианты не вызываются: они уже посчитаны для исходного скоринга. Вызовы
+// идут через ограниченный пул; при заполненной очереди теневой прогноз теряется.
+func (s *scoringService) runShadows(record *models.ScoringRecord, run *scoringRun) {
+	shadows := s.router.Shadows()
+	if len(shadows) == 0 || run.cached {
+		return
+	}
+
+	for _, variant := range shadows {
+		submitted := s.shadowPool.Submit(func(ctx context.Context) {
+			s.runShadow(ctx, variant, record, run)
+		})
+		if !submitted {
+			s.logger.Warn("Shadow pool is full, shadow prediction dropped",
+				"scoring_id", record.ID,
+				"variant", variant.Name,
+			)
+		}
+	}
+}
+
+func (s *scoringService) runShadow(ctx context.Context, variant ModelVariant, record *models.ScoringRecord, run *scoringRun) {
+	ctx, cancel := context.WithTimeout(ctx, s.router.ShadowTimeout())
+	defer cancel()
+
+	started := time.Now()
+	mlResponse, err := variant.Service.PredictWithExplanation(ctx, run.features)
+	latency := time.Since(started)
+	if err != nil {
+		s.logger.Warn("Shadow prediction failed",
+			"scoring_id", record.ID,
+			"variant", variant.Name,
+			"latency", latency,
+			"error", err,
+		)
+		return
+	}
+
+	var creditLimit float64
+	if record.Decision == dto.DecisionApproved {
+		creditLimit = s.calculateCreditLimit(run.features, mlResponse.Prediction).RecommendationCreditLimit
+	}
+
+	prediction := &models.ShadowPrediction{
+		ScoringID:       record.ID,
+		ClientID:        record.ClientID,
+		ChampionVariant: record.ModelVariant,
+		Variant:         variant.Name,
+		ModelVersion:    mlResponse.ModelVersion,
+		PipelineVersion: mlResponse.PipelineVersion,
+		PredictIncome:   mlResponse.Prediction,
+		CreditLimit:     creditLimit,
+		LatencyMs:       latency.Milliseconds(),
+	}
+	if err := s.shadowRepo.Create(ctx, prediction); err != nil {
+		s.logger.Error("Failed to save shadow prediction", "scoring_id", record.ID, "variant", variant.Name, "error", err)
+		return
+	}
+
+	s.logger.Debug("Shadow prediction saved",
+		"scoring_id", record.ID,
+		"variant", variant.Name,
+		"prediction", mlResponse.Prediction,
+		"champion_prediction", record.PredictIncome,
+	)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+)
+
+// SimulateScoring считает скоринг клиента с переопределенными признаками
+// рядом с базовым расчетом по сохраненным признакам. Результаты не сохраняются.
+func (s *scoringService) SimulateScoring(ctx context.Context, clientID int64, req *dto.SimulationRequest) (*dto.SimulationResponse, error) {
+	s.logger.Debug("Simulating scoring", "client_id", clientID, "overrides", len(req.Overrides))
+
+	if err := s.validateOverrides(req.Overrides); err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for simulation: %w", err)
+	}
+
+	features, err := s.extractFeatures(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract features: %w", err)
+	}
+
+	baseline, _, err := s.evaluate(ctx, client, features, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate baseline scoring: %w", err)
+	}
+
+	simulated, _, err := s.evaluate(ctx, client, applyOverrides(features, req.Overrides), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate simulated scoring: %w", err)
+	}
+
+	s.logger.Info("Scoring simulated",
+		"client_id", clientID,
+		"baseline_limit", baseline.RecommendationCreditLimit,
+		"simulated_limit", simulated.RecommendationCreditLimit,
+	)
+
+	return &dto.SimulationResponse{
+		ClientID:  clientID,
+		Overrides: req.Overrides,
+		Baseline:  baseline,
+		Simulated: simulated,
+		Delta: dto.ScoringDelta{
+			PredictIncome:  simulated.PredictIncome - baseline.PredictIncome,
+			CreditLimit:    simulated.RecommendationCreditLimit - baseline.RecommendationCreditLimit,
+			MaxCreditLimit: simulated.MaxCreditLimit - baseline.MaxCreditLimit,
+		},
+	}, nil
+}
+
+// validateOverrides допускает только признаки из схемы: переопределение
+// неизвестного признака модель проигнорирует, и аналитик получит тот же результат
+func (s *scoringService) validateOverrides(overrides map[string]interface{}) error {
+	var fields []domainerrors.FieldError
+	values := make(map[string]interface{}, len(overrides))
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		value := overrides[name]
+		if _, ok := s.featureSchema.Get(name); !ok {
+			fields = append(fields, domainerrors.FieldError{
+				Field:   "overrides." + name,
+				Rule:    "unknown",
+				Message: fmt.Sprintf("feature %s is not in the feature schema", name),
+			})
+			continue
+		}
+		if value != nil {
+			values[name] = value
+		}
+	}
+
+	for _, v := range s.featureSchema.Validate(values) {
+		fields = append(fields, domainerrors.FieldError{
+			Field:   "overrides." + v.Feature,
+			Rule:    v.Rule,
+			Message: v.Message,
+		})
+	}
+
+	if len(fields) > 0 {
+		return &domainerrors.ValidationError{Err: domainerrors.ErrInvalidFeatures, Fields: fields}
+	}
+	return nil
+}
+
+// applyOverrides возвращает копию признаков с наложенными переопределениями.
+// null удаляет признак, и при импутации он получает значение по умолчанию.
+func applyOverrides(features, overrides map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(features)+len(overrides))
+	for k, v := range features {
+		result[k] = v
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+)
+
+// stalePrediction прогноз из последнего сохраненного скоринга клиента
+type stalePrediction struct {
+	scoringID       int64
+	mlResponse      *dto.MLScoringResponse
+	scoredAt        time.Time
+	variant         string
+	positiveFactors []dto.Factor
+	negativeFactors []dto.Factor
+	// factorCategories в записях до появления категорий пусты
+	factorCategories []dto.FactorCategory
+}
+
+// lastKnownPrediction возвращает последний сохраненный прогноз клиента, если ML
+// недоступен, деградированный режим включен (scoring.stale_fallback), прогноз
+// не старше scoring.stale_max_age и посчитан с теми же признаками. Иначе nil.
+func (s *scoringService) lastKnownPrediction(ctx context.Context, clientID int64, features map[string]interface{}, predictErr error) *stalePrediction {
+	if !s.cfg.StaleFallback || !errors.Is(predictErr, domainerrors.ErrMLServiceUnavailable) {
+		return nil
+	}
+
+	record, err := s.scoringRepo.GetLatestByClientID(ctx, clientID)
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrScoringNotFound) {
+			s.logger.Error("Failed to load last scoring for stale fallback", "client_id", clientID, "error", err)
+		}
+		return nil
+	}
+
+	scoredAt := record.CreatedAt
+	if record.ScoredAt != nil && !record.ScoredAt.IsZero() {
+		scoredAt = *record.ScoredAt
+	}
+	if s.cfg.StaleMaxAge > 0 && time.Since(scoredAt) > time.Duration(s.cfg.StaleMaxAge)*time.Second {
+		s.logger.Info("Last scoring is too old for stale fallback", "client_id", clientID, "scoring_id", record.ID, "scored_at", scoredAt)
+		return nil
+	}
+
+	current, err := json.Marshal(features)
+	if err != nil {
+		return nil
+	}
+	var stored map[string]interface{}
+	if len(record.Features) == 0 || json.Unmarshal(record.Features, &stored) != nil {
+		return nil
+	}
+	// повторный Marshal приводит оба набора к одному виду: ключи отсортированы, числа в одном формате
+	storedJSON, err := json.Marshal(stored)
+	if err != nil || !bytes.Equal(current, storedJSON) {
+		s.logger.Info("Client features changed since last scoring, stale fallback skipped", "client_id", clientID, "scoring_id", record.ID)
+		return nil
+	}
+
+	response, err := dto.FromScoringRecord(record)
+	if err != nil {
+		s.logger.Error("Failed to decode last scoring for stale fallback", "client_id", clientID, "scoring_id", record.ID, "error", err)
+		return nil
+	}
+
+	s.logger.Warn("ML service unavailable, serving stale prediction",
+		"client_id", clientID,
+		"scoring_id", record.ID,
+		"scored_at", scoredAt,
+		"error", predictErr,
+	)
+	return &stalePrediction{
+		scoringID: record.ID,
+		mlResponse: &dto.MLScoringResponse{
+			Prediction:      record.PredictIncome,
+			ID:              record.MLUID,
+			ModelVersion:    record.ModelVersion,
+			PipelineVersion: record.PipelineVersion,
+		},
+		scoredAt:         scoredAt,
+		variant:          record.ModelVariant,
+		positiveFactors:  response.PositiveFactors,
+		negativeFactors:  response.NegativeFactors,
+		factorCategories: response.FactorCategories,
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/google/uuid"
+)
+
+// TrainingExporter периодически отправляет неотправленные размеченные примеры
+// в ML-сервис через SendTrainingData. Неудачная отправка повторяется с
+// экспоненциальной задержкой, после max_attempts пример помечается failed.
+// Примеры забираются атомарно, поэтому экспортер может работать в нескольких
+// экземплярах сервиса без повторной отправки.
+type TrainingExporter struct {
+	trainingRepo interfaces.TrainingRepository
+	mlService    interfaces.MLService
+	cfg          config.TrainingConfig
+	logger       interfaces.Logger
+}
+
+func NewTrainingExporter(
+	trainingRepo interfaces.TrainingRepository,
+	mlService interfaces.MLService,
+	cfg config.TrainingConfig,
+	logger interfaces.Logger,
+) *TrainingExporter {
+	return &TrainingExporter{
+		trainingRepo: trainingRepo,
+		mlService:    mlService,
+		cfg:          cfg,
+		logger:       logger.With("component", "TrainingExporter"),
+	}
+}
+
+// Run выгружает примеры раз в cfg.Interval до отмены контекста
+func (e *TrainingExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(e.cfg.Interval) * time.Second)
+	defer ticker.Stop()
+
+	e.logger.Info("Training exporter started", "interval", e.cfg.Interval, "batch_size", e.cfg.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info("Training exporter stopped")
+			return
+		case <-ticker.C:
+			if _, err := e.ExportOnce(ctx); err != nil && ctx.Err() == nil {
+				e.logger.Error("Training export failed", "error", err)
+			}
+		}
+	}
+}
+
+// ExportOnce отправляет все примеры, у которых подошло время попытки,
+// пакетами по cfg.BatchSize и возвращает число доставленных
+func (e *TrainingExporter) ExportOnce(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		now := time.Now()
+		samples, err := e.trainingRepo.ClaimDue(ctx, now, e.cfg.BatchSize, time.Duration(e.cfg.ClaimTimeout)*time.Second)
+		if err != nil {
+			return sent, err
+		}
+		if len(samples) == 0 {
+			return sent, nil
+		}
+
+		batch := dto.TrainingBatch{
+			BatchID: uuid.New().String(),
+			Samples: make([]dto.TrainingRow, 0, len(samples)),
+		}
+		ids := make([]int64, 0, len(samples))
+		for _, sample := range samples {
+			ids = append(ids, sample.ID)
+			batch.Samples = append(batch.Samples, dto.TrainingRow{
+				SampleID:        sample.ID,
+				ClientID:        sample.ClientID,
+				Features:        []byte(sample.Features),
+				Target:          sample.Target,
+				PredictIncome:   sample.PredictIncome,
+				ModelVersion:    sample.ModelVersion,
+				PipelineVersion: sample.PipelineVersion,
+			})
+		}
+
+		if err := e.mlService.SendTrainingData(ctx, batch); err != nil {
+			e.logger.Warn("Failed to deliver training batch", "batch_id", batch.BatchID, "samples", len(ids), "error", err)
+			backoff := time.Duration(e.cfg.RetryBackoff) * time.Second
+			if markErr := e.trainingRepo.MarkFailed(ctx, ids, batch.BatchID, err.Error(), now, backoff, e.cfg.MaxAttempts); markErr != nil {
+				return sent, markErr
+			}
+			// ML-сервис недоступен - остальные пакеты подождут следующего запуска
+			return sent, fmt.Errorf("failed to deliver training batch %s: %w", batch.BatchID, err)
+		}
+
+		if err := e.trainingRepo.MarkSent(ctx, ids, batch.BatchID, time.Now()); err != nil {
+			return sent, err
+		}
+		sent += len(ids)
+		e.logger.Info("Training batch delivered", "batch_id", batch.BatchID, "samples", len(ids))
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+type trainingService struct {
+	clientRepo   interfaces.ClientRepository
+	scoringRepo  interfaces.ScoringRepository
+	trainingRepo interfaces.TrainingRepository
+	quality      config.QualityConfig
+	logger       interfaces.Logger
+}
+
+func NewTrainingService(
+	clientRepo interfaces.ClientRepository,
+	scoringRepo interfaces.ScoringRepository,
+	trainingRepo interfaces.TrainingRepository,
+	quality config.QualityConfig,
+	logger interfaces.Logger,
+) interfaces.TrainingService {
+	return &trainingService{
+		clientRepo:   clientRepo,
+		scoringRepo:  scoringRepo,
+		trainingRepo: trainingRepo,
+		quality:      quality,
+		logger:       logger.With("component", "TrainingService"),
+	}
+}
+
+// ConfirmIncome сохраняет подтвержденный доход и размечает им признаки последнего
+// скоринга клиента. Без скоринга с сохраненными признаками пример не создается.
+func (s *trainingService) ConfirmIncome(ctx context.Context, clientID int64, req *dto.IncomeConfirmationRequest) (*dto.IncomeConfirmationResponse, error) {
+	confirmedAt, err := time.Parse(dto.DateFormat, req.ConfirmedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: confirmed_at: %w", domainerrors.ErrInvalidFormat, err)
+	}
+
+	if _, err := s.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	confirmation := &models.IncomeConfirmation{
+		ClientID:    clientID,
+		Income:      req.Income,
+		Source:      req.Source,
+		ConfirmedAt: confirmedAt,
+	}
+
+	sample, err := s.buildSample(ctx, clientID, req.Income)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.trainingRepo.CreateConfirmation(ctx, confirmation, sample); err != nil {
+		return nil, fmt.Errorf("failed to save income confirmation: %w", err)
+	}
+
+	if sample != nil {
+		s.logger.Info("Income confirmed", "client_id", clientID, "scoring_id", sample.ScoringID, "sample_id", sample.ID)
+	} else {
+		s.logger.Warn("Income confirmed without scoring snapshot, no training sample created", "client_id", clientID)
+	}
+	return dto.FromIncomeConfirmation(confirmation, sample), nil
+}
+
+func (s *trainingService) buildSample(ctx context.Context, clientID int64, income float64) (*models.TrainingSample, error) {
+	record, err := s.scoringRepo.GetLatestByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrScoringNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest scoring: %w", err)
+	}
+	// записи, сохраненные до появления снимка признаков
+	if len(record.Features) == 0 || string(record.Features) == "null" {
+		return nil, nil
+	}
+
+	return &models.TrainingSample{
+		ClientID:        clientID,
+		ScoringID:       record.ID,
+		Features:        record.Features,
+		Target:          income,
+		PredictIncome:   record.PredictIncome,
+		ModelVersion:    record.ModelVersion,
+		PipelineVersion: record.PipelineVersion,
+		Status:          models.TrainingPending,
+		NextAttemptAt:   time.Now(),
+	}, nil
+}
+
+func (s *trainingService) GetDeliveryStats(ctx context.Context) (*dto.TrainingDeliveryStatsResponse, error) {
+	counts, err := s.trainingRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count training samples: %w", err)
+	}
+
+	lastSentAt, err := s.trainingRepo.LastSentAt(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last training delivery: %w", err)
+	}
+
+	response := &dto.TrainingDeliveryStatsResponse{LastSentAt: lastSentAt}
+	for _, count := range counts {
+		switch count.Status {
+		case models.TrainingPending:
+			response.Pending = count.Count
+		case models.TrainingSending:
+			response.Sending = count.Count
+		case models.TrainingSent:
+			response.Sent = count.Count
+		case models.TrainingFailed:
+			response.Failed = count.Count
+		}
+	}
+	return response, nil
+}
@@ -7,10 +7,22 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Version версия сборки, задается при сборке:
+// go build -ldflags "-X github.com/Godrik0/HackChange-Alpha/backend/internal/config.Version=1.2.3"
+var Version = "dev"
+
 type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Database DatabaseConfig `mapstructure:"database"`
 	ML       MLConfig       `mapstructure:"ml"`
+	Scoring  ScoringConfig  `mapstructure:"scoring"`
+	Features FeaturesConfig `mapstructure:"features"`
+	Offers   OffersConfig   `mapstructure:"offers"`
+	Promo    PromoConfig    `mapstructure:"promo"`
+	Training TrainingConfig `mapstructure:"training"`
+	Quality  QualityConfig  `mapstructure:"model_quality"`
+	Drift    DriftConfig    `mapstructure:"drift"`
+	Health   HealthConfig   `mapstructure:"health"`
 	Log      LogConfig      `mapstructure:"log"`
 }
 
@@ -32,10 +44,441 @@ type DatabaseConfig struct {
 }
 
 type MLConfig struct {
-	BaseURL         string `mapstructure:"base_url"`
-	Timeout         int    `mapstructure:"timeout"`
-	ModelVersion    string `mapstructure:"model_version"`
+	Backend         string        `mapstructure:"backend"`
+	FallbackToLocal bool          `mapstructure:"fallback_to_local"`
+	BaseURL         string        `mapstructure:"base_url"`
+	Timeout         int           `mapstructure:"timeout"`
+	ModelVersion    string        `mapstructure:"model_version"`
+	PipelineVersion string        `mapstructure:"pipeline_version"`
+	Retry           RetryConfig   `mapstructure:"retry"`
+	Breaker         BreakerConfig `mapstructure:"breaker"`
+	Local           LocalMLConfig `mapstructure:"local"`
+	Routing         RoutingConfig `mapstructure:"routing"`
+}
+
+// RoutingConfig варианты модели champion/challenger. Пустой список - весь трафик
+// идет в model_version/pipeline_version из секции ml.
+type RoutingConfig struct {
+	// Salt соль хеша client ID; смена соли перераспределяет клиентов по вариантам
+	Salt string `mapstructure:"salt"`
+	// AgreementTolerance относительная разница прогнозов, при которой варианты согласны
+	AgreementTolerance float64 `mapstructure:"agreement_tolerance"`
+	// ShadowTimeout секунды на фоновый запрос к теневому варианту
+	ShadowTimeout int `mapstructure:"shadow_timeout"`
+	// ShadowWorkers одновременные фоновые запросы к теневым вариантам
+	ShadowWorkers int `mapstructure:"shadow_workers"`
+	// ShadowQueueSize очередь теневых запросов; сверх нее запросы отбрасываются
+	ShadowQueueSize int                  `mapstructure:"shadow_queue_size"`
+	Variants        []ModelVariantConfig `mapstructure:"variants"`
+}
+
+// ModelVariantConfig вариант модели. Боевые варианты делят трафик по весам,
+// первый из них - champion. Теневой вариант вызывается в фоне для каждого
+// сохраняемого скоринга, его прогноз сохраняется, но не возвращается.
+type ModelVariantConfig struct {
+	Name         string `mapstructure:"name"`
+	ModelVersion string `mapstructure:"model_version"`
+	// PipelineVersion по умолчанию ml.pipeline_version
 	PipelineVersion string `mapstructure:"pipeline_version"`
+	Weight          int    `mapstructure:"weight"`
+	Shadow          bool   `mapstructure:"shadow"`
+}
+
+func (r *RoutingConfig) Validate() error {
+	if r.AgreementTolerance <= 0 {
+		return fmt.Errorf("agreement_tolerance must be positive")
+	}
+	if r.ShadowTimeout <= 0 {
+		return fmt.Errorf("shadow_timeout must be positive")
+	}
+	if r.ShadowWorkers <= 0 {
+		return fmt.Errorf("shadow_workers must be positive")
+	}
+	if r.ShadowQueueSize < 0 {
+		return fmt.Errorf("shadow_queue_size must not be negative")
+	}
+	if len(r.Variants) == 0 {
+		return nil
+	}
+
+	names := make(map[string]struct{}, len(r.Variants))
+	totalWeight := 0
+	for _, variant := range r.Variants {
+		if variant.Name == "" {
+			return fmt.Errorf("variant name is required")
+		}
+		if _, ok := names[variant.Name]; ok {
+			return fmt.Errorf("duplicate variant %q", variant.Name)
+		}
+		names[variant.Name] = struct{}{}
+
+		if variant.ModelVersion == "" {
+			return fmt.Errorf("variant %s: model_version is required", variant.Name)
+		}
+		if variant.Weight < 0 {
+			return fmt.Errorf("variant %s: weight must not be negative", variant.Name)
+		}
+		if variant.Shadow && variant.Weight > 0 {
+			return fmt.Errorf("variant %s: shadow variant must not have weight", variant.Name)
+		}
+		totalWeight += variant.Weight
+	}
+	if totalWeight == 0 {
+		return fmt.Errorf("at least one variant must have positive weight")
+	}
+	return nil
+}
+
+// LocalMLConfig настройки встроенной модели (ml.backend: local или fallback)
+type LocalMLConfig struct {
+	ModelPath    string  `mapstructure:"model_path"`
+	ModelVersion string  `mapstructure:"model_version"`
+	BaseScore    float64 `mapstructure:"base_score"`
+}
+
+type RetryConfig struct {
+	MaxRetries  int `mapstructure:"max_retries"`
+	BaseDelayMs int `mapstructure:"base_delay_ms"`
+	MaxDelayMs  int `mapstructure:"max_delay_ms"`
+}
+
+type BreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	OpenTimeout      int `mapstructure:"open_timeout"`
+}
+
+type ScoringConfig struct {
+	BatchConcurrency int `mapstructure:"batch_concurrency"`
+	BatchMaxSize     int `mapstructure:"batch_max_size"`
+	// BatchTimeout секунды на обработку пакетного скоринга; заменяет общий
+	// таймаут запроса и server.write_timeout для этого маршрута
+	BatchTimeout int `mapstructure:"batch_timeout"`
+
+	// MinDataCompleteness минимальная доля признаков, переданных с данными клиента.
+	// Ниже порога скоринг помечается insufficient_data или отклоняется.
+	MinDataCompleteness    float64 `mapstructure:"min_data_completeness"`
+	RejectInsufficientData bool    `mapstructure:"reject_insufficient_data"`
+
+	// StaleFallback при недоступности ML отдавать последний сохраненный прогноз
+	// клиента, если его признаки с тех пор не менялись. Выключается для
+	// регулируемых процессов, где нужен только свежий прогноз.
+	StaleFallback bool `mapstructure:"stale_fallback"`
+	// StaleMaxAge секунды, старше которых сохраненный прогноз не используется; 0 - без ограничения
+	StaleMaxAge int `mapstructure:"stale_max_age"`
+
+	CreditPolicy CreditPolicyConfig  `mapstructure:"credit_policy"`
+	DeclineRules []DeclineRuleConfig `mapstructure:"decline_rules"`
+
+	Cache   PredictionCacheConfig `mapstructure:"cache"`
+	Factors FactorsConfig         `mapstructure:"factors"`
+}
+
+// FactorsConfig факторы скоринга в ответе
+type FactorsConfig struct {
+	// TopN сколько положительных и отрицательных факторов с наибольшим
+	// по модулю вкладом возвращать
+	TopN int `mapstructure:"top_n"`
+
+	// Categories группы признаков по префиксу имени для суммарного вклада
+	// по категориям; признак относится к категории с самым длинным подходящим
+	// префиксом, остальные - к OtherCategory
+	Categories    []FactorCategoryConfig `mapstructure:"categories"`
+	OtherCategory string                 `mapstructure:"other_category"`
+}
+
+// FactorCategoryConfig префикс имени признака и категория, к которой он относится
+type FactorCategoryConfig struct {
+	Prefix   string `mapstructure:"prefix"`
+	Category string `mapstructure:"category"`
+}
+
+func (c *FactorsConfig) Validate() error {
+	if c.TopN <= 0 {
+		return fmt.Errorf("top_n must be positive")
+	}
+	if c.OtherCategory == "" {
+		return fmt.Errorf("other_category is required")
+	}
+	prefixes := make(map[string]bool, len(c.Categories))
+	for i, category := range c.Categories {
+		if category.Prefix == "" {
+			return fmt.Errorf("categories[%d]: prefix is required", i)
+		}
+		if category.Category == "" {
+			return fmt.Errorf("categories[%d]: category is required", i)
+		}
+		if prefixes[category.Prefix] {
+			return fmt.Errorf("categories[%d]: duplicate prefix %q", i, category.Prefix)
+		}
+		prefixes[category.Prefix] = true
+	}
+	return nil
+}
+
+// PredictionCacheConfig кэш прогнозов ML по отпечатку признаков и версии модели
+type PredictionCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTL секунды жизни прогноза в кэше
+	TTL        int `mapstructure:"ttl"`
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func (c *PredictionCacheConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.TTL <= 0 {
+		return fmt.Errorf("ttl must be positive")
+	}
+	if c.MaxEntries <= 0 {
+		return fmt.Errorf("max_entries must be positive")
+	}
+	return nil
+}
+
+// DeclineRuleConfig правило жесткого отказа: feature <operator> value.
+// Кроме признаков модели доступна переменная client_age, вычисляемая из даты рождения.
+type DeclineRuleConfig struct {
+	Code     string  `mapstructure:"code"`
+	Feature  string  `mapstructure:"feature"`
+	Operator string  `mapstructure:"operator"`
+	Value    float64 `mapstructure:"value"`
+	Message  string  `mapstructure:"message"`
+}
+
+func (r *DeclineRuleConfig) Validate() error {
+	if r.Code == "" {
+		return fmt.Errorf("decline rule code is required")
+	}
+	if r.Feature == "" {
+		return fmt.Errorf("decline rule %s: feature is required", r.Code)
+	}
+	switch r.Operator {
+	case "gt", "gte", "lt", "lte", "eq", "ne":
+	default:
+		return fmt.Errorf("decline rule %s: unknown operator %q", r.Code, r.Operator)
+	}
+	if r.Message == "" {
+		return fmt.Errorf("decline rule %s: message is required", r.Code)
+	}
+	return nil
+}
+
+// CreditPolicyConfig параметры расчета кредитного лимита.
+// Version записывается в каждый результат скоринга для аудита.
+type CreditPolicyConfig struct {
+	Version             string  `mapstructure:"version"`
+	LegalPDN            float64 `mapstructure:"legal_pdn"`
+	BankPDN             float64 `mapstructure:"bank_pdn"`
+	AmortizationMonths  int     `mapstructure:"amortization_months"`
+	FallbackDenominator float64 `mapstructure:"fallback_denominator"`
+	MaxOverdueSum       float64 `mapstructure:"max_overdue_sum"`
+}
+
+func (p *CreditPolicyConfig) Validate() error {
+	if p.Version == "" {
+		return fmt.Errorf("credit policy version is required")
+	}
+	if p.LegalPDN <= 0 || p.LegalPDN > 1 {
+		return fmt.Errorf("credit policy legal_pdn must be in (0, 1], got %v", p.LegalPDN)
+	}
+	if p.BankPDN <= 0 || p.BankPDN > p.LegalPDN {
+		return fmt.Errorf("credit policy bank_pdn must be in (0, legal_pdn], got %v", p.BankPDN)
+	}
+	if p.AmortizationMonths <= 0 {
+		return fmt.Errorf("credit policy amortization_months must be positive, got %d", p.AmortizationMonths)
+	}
+	if p.FallbackDenominator <= 0 {
+		return fmt.Errorf("credit policy fallback_denominator must be positive, got %v", p.FallbackDenominator)
+	}
+	if p.MaxOverdueSum < 0 {
+		return fmt.Errorf("credit policy max_overdue_sum must not be negative, got %v", p.MaxOverdueSum)
+	}
+	return nil
+}
+
+// OffersConfig кредитные продукты и их тарифные сетки для расчета предложений
+type OffersConfig struct {
+	Products []LoanProductConfig `mapstructure:"products"`
+}
+
+type LoanProductConfig struct {
+	Type  string       `mapstructure:"type"`
+	Name  string       `mapstructure:"name"`
+	Rates []RateConfig `mapstructure:"rates"`
+}
+
+// RateConfig строка тарифной сетки. MaxAmount и MaxTerm равные 0 - без верхней границы.
+type RateConfig struct {
+	MinAmount  float64 `mapstructure:"min_amount"`
+	MaxAmount  float64 `mapstructure:"max_amount"`
+	MinTerm    int     `mapstructure:"min_term"`
+	MaxTerm    int     `mapstructure:"max_term"`
+	AnnualRate float64 `mapstructure:"annual_rate"`
+}
+
+func (p *LoanProductConfig) Validate() error {
+	if p.Type == "" {
+		return fmt.Errorf("product type is required")
+	}
+	if len(p.Rates) == 0 {
+		return fmt.Errorf("product %s has no rates", p.Type)
+	}
+	for i, rate := range p.Rates {
+		if rate.AnnualRate < 0 {
+			return fmt.Errorf("product %s rate #%d: annual_rate must not be negative", p.Type, i)
+		}
+		if rate.MaxAmount > 0 && rate.MaxAmount < rate.MinAmount {
+			return fmt.Errorf("product %s rate #%d: max_amount is less than min_amount", p.Type, i)
+		}
+		if rate.MaxTerm > 0 && rate.MaxTerm < rate.MinTerm {
+			return fmt.Errorf("product %s rate #%d: max_term is less than min_term", p.Type, i)
+		}
+	}
+	return nil
+}
+
+type PromoConfig struct {
+	// CacheTTL секунды, в течение которых каталог промо берется из памяти
+	CacheTTL int `mapstructure:"cache_ttl"`
+	// StatsIncomeBands возрастающие границы диапазонов прогноза дохода в статистике промо
+	StatsIncomeBands []float64 `mapstructure:"stats_income_bands"`
+}
+
+func (p *PromoConfig) Validate() error {
+	if len(p.StatsIncomeBands) == 0 {
+		return fmt.Errorf("stats_income_bands must not be empty")
+	}
+	for i := 1; i < len(p.StatsIncomeBands); i++ {
+		if p.StatsIncomeBands[i] <= p.StatsIncomeBands[i-1] {
+			return fmt.Errorf("stats_income_bands must be strictly increasing")
+		}
+	}
+	return nil
+}
+
+// TrainingConfig выгрузка размеченных примеров в ML-сервис (/api/train)
+type TrainingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval секунды между запусками выгрузки
+	Interval  int `mapstructure:"interval"`
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxAttempts попытки отправки примера, после которых он помечается failed
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RetryBackoff секунды до первой повторной попытки, дальше удваиваются
+	RetryBackoff int `mapstructure:"retry_backoff"`
+	// ClaimTimeout секунды, на которые пакет закрепляется за экспортером; если
+	// отправка не завершилась (процесс упал), пакет снова становится доступным
+	ClaimTimeout int `mapstructure:"claim_timeout"`
+}
+
+func (t *TrainingConfig) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if t.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+	if t.MaxAttempts <= 0 {
+		return fmt.Errorf("max_attempts must be positive")
+	}
+	if t.RetryBackoff < 0 {
+		return fmt.Errorf("retry_backoff must not be negative")
+	}
+	if t.ClaimTimeout <= 0 {
+		return fmt.Errorf("claim_timeout must be positive")
+	}
+	return nil
+}
+
+// QualityConfig разбивки отчета о качестве модели
+type QualityConfig struct {
+	// IncomeBands возрастающие границы диапазонов подтвержденного дохода
+	IncomeBands []float64 `mapstructure:"income_bands"`
+	// AgeGroups возрастающие границы возрастных групп, лет
+	AgeGroups []int `mapstructure:"age_groups"`
+	// RegionFeature признак, по которому группируются регионы
+	RegionFeature string `mapstructure:"region_feature"`
+}
+
+func (q *QualityConfig) Validate() error {
+	if len(q.IncomeBands) == 0 {
+		return fmt.Errorf("income_bands must not be empty")
+	}
+	for i := 1; i < len(q.IncomeBands); i++ {
+		if q.IncomeBands[i] <= q.IncomeBands[i-1] {
+			return fmt.Errorf("income_bands must be strictly increasing")
+		}
+	}
+	if len(q.AgeGroups) == 0 {
+		return fmt.Errorf("age_groups must not be empty")
+	}
+	for i := 1; i < len(q.AgeGroups); i++ {
+		if q.AgeGroups[i] <= q.AgeGroups[i-1] {
+			return fmt.Errorf("age_groups must be strictly increasing")
+		}
+	}
+	if q.RegionFeature == "" {
+		return fmt.Errorf("region_feature is required")
+	}
+	return nil
+}
+
+// DriftConfig мониторинг дрейфа признаков и прогнозов относительно эталона
+type DriftConfig struct {
+	// Bins число квантильных бинов эталонного распределения
+	Bins int `mapstructure:"bins"`
+	// WindowDays дни последнего трафика скоринга, который сравнивается с эталоном
+	WindowDays int `mapstructure:"window_days"`
+	// MaxSample максимум скорингов в окне и в эталоне
+	MaxSample int `mapstructure:"max_sample"`
+	// PSIAlert порог PSI, выше которого признак попадает в алерты
+	PSIAlert float64 `mapstructure:"psi_alert"`
+	// MissingRateAlert порог изменения доли пропусков (по модулю)
+	MissingRateAlert float64 `mapstructure:"missing_rate_alert"`
+	// CheckInterval секунды между фоновыми проверками, 0 - проверка только по запросу
+	CheckInterval int `mapstructure:"check_interval"`
+}
+
+func (d *DriftConfig) Validate() error {
+	if d.Bins < 2 {
+		return fmt.Errorf("bins must be at least 2")
+	}
+	if d.WindowDays <= 0 {
+		return fmt.Errorf("window_days must be positive")
+	}
+	if d.MaxSample <= 0 {
+		return fmt.Errorf("max_sample must be positive")
+	}
+	if d.PSIAlert <= 0 || d.MissingRateAlert <= 0 {
+		return fmt.Errorf("alert thresholds must be positive")
+	}
+	if d.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must not be negative")
+	}
+	return nil
+}
+
+// HealthConfig проверки зависимостей для /readyz
+type HealthConfig struct {
+	// Timeout секунды на проверку одной зависимости
+	Timeout int `mapstructure:"timeout"`
+	// MLRequired недоступность ML-сервиса переводит сервис в not_ready
+	MLRequired bool `mapstructure:"ml_required"`
+}
+
+func (h *HealthConfig) Validate() error {
+	if h.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	return nil
+}
+
+type FeaturesConfig struct {
+	SchemaPath string `mapstructure:"schema_path"`
 }
 
 type LogConfig struct {
@@ -69,6 +512,47 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("unable to decode config into struct: %w", err)
 	}
 
+	if cfg.Scoring.BatchTimeout <= 0 {
+		return nil, fmt.Errorf("invalid scoring.batch_timeout: must be positive")
+	}
+	if err := cfg.Scoring.CreditPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring.credit_policy: %w", err)
+	}
+	if err := cfg.Scoring.Cache.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring.cache: %w", err)
+	}
+	if err := cfg.Scoring.Factors.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring.factors: %w", err)
+	}
+	if err := cfg.Promo.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid promo: %w", err)
+	}
+	if err := cfg.Training.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid training: %w", err)
+	}
+	if err := cfg.Quality.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model_quality: %w", err)
+	}
+	if err := cfg.Drift.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid drift: %w", err)
+	}
+	if err := cfg.ML.Routing.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid ml.routing: %w", err)
+	}
+	if err := cfg.Health.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid health: %w", err)
+	}
+	for i := range cfg.Scoring.DeclineRules {
+		if err := cfg.Scoring.DeclineRules[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scoring.decline_rules[%d]: %w", i, err)
+		}
+	}
+	for i := range cfg.Offers.Products {
+		if err := cfg.Offers.Products[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid offers.products[%d]: %w", i, err)
+		}
+	}
+
 	return &cfg, nil
 }
 
@@ -86,10 +570,108 @@ func setDefaults() {
 	viper.SetDefault("database.dbname", "hackchange")
 	viper.SetDefault("database.sslmode", "disable")
 
+	viper.SetDefault("ml.backend", "http")
+	viper.SetDefault("ml.fallback_to_local", false)
 	viper.SetDefault("ml.base_url", "http://localhost:5000")
 	viper.SetDefault("ml.timeout", 30)
 	viper.SetDefault("ml.model_version", "v1.0")
 	viper.SetDefault("ml.pipeline_version", "1.0")
+	viper.SetDefault("ml.retry.max_retries", 2)
+	viper.SetDefault("ml.retry.base_delay_ms", 200)
+	viper.SetDefault("ml.retry.max_delay_ms", 2000)
+	viper.SetDefault("ml.breaker.failure_threshold", 5)
+	viper.SetDefault("ml.breaker.open_timeout", 30)
+	viper.SetDefault("ml.local.model_path", "./models/model.json")
+	viper.SetDefault("ml.local.base_score", 0.0)
+	viper.SetDefault("ml.routing.salt", "")
+	viper.SetDefault("ml.routing.agreement_tolerance", 0.1)
+	viper.SetDefault("ml.routing.shadow_timeout", 30)
+	viper.SetDefault("ml.routing.shadow_workers", 4)
+	viper.SetDefault("ml.routing.shadow_queue_size", 100)
+
+	viper.SetDefault("scoring.batch_concurrency", 8)
+	viper.SetDefault("scoring.batch_max_size", 500)
+	viper.SetDefault("scoring.batch_timeout", 300)
+	viper.SetDefault("scoring.min_data_completeness", 0.5)
+	viper.SetDefault("scoring.reject_insufficient_data", false)
+	viper.SetDefault("scoring.stale_fallback", true)
+	viper.SetDefault("scoring.stale_max_age", 0)
+	viper.SetDefault("scoring.cache.enabled", true)
+	viper.SetDefault("scoring.cache.ttl", 300)
+	viper.SetDefault("scoring.cache.max_entries", 10000)
+	viper.SetDefault("scoring.factors.top_n", 10)
+	viper.SetDefault("scoring.factors.other_category", "Прочее")
+	viper.SetDefault("scoring.factors.categories", []map[string]interface{}{
+		{"prefix": "hdb_bki_", "category": "Кредитная история"},
+		{"prefix": "bki_", "category": "Кредитная история"},
+		{"prefix": "dp_ils_", "category": "Зарплатные данные"},
+		{"prefix": "dp_payoutincomedata_", "category": "Зарплатные данные"},
+		{"prefix": "turn_", "category": "Обороты по счетам"},
+		{"prefix": "avg_by_category__", "category": "Траты"},
+		{"prefix": "amount_by_category_90d__", "category": "Траты"},
+		{"prefix": "transaction_category_", "category": "Траты"},
+		{"prefix": "vert_", "category": "Цифровое поведение"},
+		{"prefix": "mob_", "category": "Цифровое поведение"},
+	})
+	viper.SetDefault("scoring.credit_policy.version", "2024-01")
+	viper.SetDefault("scoring.credit_policy.legal_pdn", 0.50)
+	viper.SetDefault("scoring.credit_policy.bank_pdn", 0.40)
+	viper.SetDefault("scoring.credit_policy.amortization_months", 36)
+	viper.SetDefault("scoring.credit_policy.fallback_denominator", 0.15)
+	viper.SetDefault("scoring.credit_policy.max_overdue_sum", 0.0)
+	viper.SetDefault("scoring.decline_rules", []map[string]interface{}{
+		{"code": "UNDERAGE", "feature": "client_age", "operator": "lt", "value": 18, "message": "Клиенту меньше 18 лет"},
+		{"code": "NONRESIDENT", "feature": "nonresident_flag", "operator": "eq", "value": 1, "message": "Клиент не является налоговым резидентом РФ"},
+		{"code": "BLACKLIST", "feature": "blacklist_flag", "operator": "eq", "value": 1, "message": "Клиент находится в черном списке банка"},
+		{"code": "CURRENT_OVERDUE", "feature": "ovrd_sum", "operator": "gt", "value": 0, "message": "Есть текущая просроченная задолженность"},
+		{"code": "BKI_MAX_OVERDUE", "feature": "hdb_bki_total_max_overdue_sum", "operator": "gt", "value": 50000, "message": "Максимальная просрочка по данным БКИ превышает допустимую"},
+		{"code": "BKI_DELINQUENCY_90", "feature": "hdb_bki_total_pil_max_del90", "operator": "gt", "value": 0, "message": "В кредитной истории есть просрочка более 90 дней"},
+	})
+
+	viper.SetDefault("features.schema_path", "")
+
+	viper.SetDefault("promo.cache_ttl", 30)
+	viper.SetDefault("promo.stats_income_bands", []float64{30000, 60000, 120000, 250000, 500000, 1000000})
+
+	viper.SetDefault("training.enabled", true)
+	viper.SetDefault("training.interval", 300)
+	viper.SetDefault("training.batch_size", 100)
+	viper.SetDefault("training.max_attempts", 5)
+	viper.SetDefault("training.retry_backoff", 60)
+	viper.SetDefault("training.claim_timeout", 600)
+
+	viper.SetDefault("model_quality.income_bands", []float64{30000, 60000, 120000, 250000, 500000, 1000000})
+	viper.SetDefault("model_quality.age_groups", []int{25, 35, 45, 55, 65})
+	viper.SetDefault("model_quality.region_feature", "region")
+
+	viper.SetDefault("drift.bins", 10)
+	viper.SetDefault("drift.window_days", 7)
+	viper.SetDefault("drift.max_sample", 5000)
+	viper.SetDefault("drift.psi_alert", 0.2)
+	viper.SetDefault("drift.missing_rate_alert", 0.1)
+	viper.SetDefault("drift.check_interval", 3600)
+
+	viper.SetDefault("health.timeout", 2)
+	viper.SetDefault("health.ml_required", true)
+
+	viper.SetDefault("offers.products", []map[string]interface{}{
+		{
+			"type": "cash_loan",
+			"name": "Кредит наличными",
+			"rates": []map[string]interface{}{
+				{"min_amount": 30000, "max_amount": 300000, "min_term": 6, "max_term": 60, "annual_rate": 0.299},
+				{"min_amount": 300000, "max_amount": 1000000, "min_term": 12, "max_term": 60, "annual_rate": 0.249},
+				{"min_amount": 1000000, "max_amount": 5000000, "min_term": 12, "max_term": 84, "annual_rate": 0.219},
+			},
+		},
+		{
+			"type": "refinancing",
+			"name": "Рефинансирование",
+			"rates": []map[string]interface{}{
+				{"min_amount": 100000, "max_amount": 5000000, "min_term": 12, "max_term": 84, "annual_rate": 0.199},
+			},
+		},
+	})
 
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
@@ -15,7 +15,7 @@ type CreateClientRequest struct {
 	FirstName  string                 `json:"first_name" validate:"required,min=1,max=100"`
 	LastName   string                 `json:"last_name" validate:"required,min=1,max=100"`
 	MiddleName string                 `json:"middle_name" validate:"omitempty,max=100"`
-	BirthDate  string                 `json:"birth_date" validate:"required"`
+	BirthDate  string                 `json:"birth_date" validate:"required,datetime=02-01-2006"`
 	Features   map[string]interface{} `json:"features,omitempty"`
 }
 
@@ -47,6 +47,15 @@ func (s SearchParams) IsEmpty() bool {
 	return s.FirstName == "" && s.LastName == "" && s.MiddleName == "" && s.BirthDate == ""
 }
 
+// AgeAt возвращает полное число лет на дату now
+func AgeAt(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
 func (r *CreateClientRequest) ToModel() (*models.Client, error) {
 	parsedBirthDate, err := time.Parse(DateFormat, r.BirthDate)
 	if err != nil {
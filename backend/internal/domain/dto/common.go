@@ -10,3 +10,14 @@ type SuccessResponse struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+type ValidationError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type ValidationErrorResponse struct {
+	Error  string            `json:"error"`
+	Errors []ValidationError `json:"errors"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// Причины алерта дрейфа
+const (
+	DriftAlertPSI         = "psi"
+	DriftAlertMissingRate = "missing_rate"
+)
+
+type DriftReferenceResponse struct {
+	WindowFrom time.Time `json:"window_from"`
+	WindowTo   time.Time `json:"window_to"`
+	SampleSize int       `json:"sample_size"`
+	Features   int       `json:"features"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DriftItem дрейф одного признака или прогноза: PSI распределения непропущенных
+// значений и изменение доли пропусков (импутированных значений)
+type DriftItem struct {
+	Name                 string   `json:"name"`
+	PSI                  float64  `json:"psi"`
+	ReferenceMissingRate float64  `json:"reference_missing_rate"`
+	CurrentMissingRate   float64  `json:"current_missing_rate"`
+	MissingRateDelta     float64  `json:"missing_rate_delta"`
+	Alert                bool     `json:"alert"`
+	AlertReasons         []string `json:"alert_reasons,omitempty" enums:"psi,missing_rate"`
+}
+
+type DriftThresholds struct {
+	PSI         float64 `json:"psi"`
+	MissingRate float64 `json:"missing_rate"`
+}
+
+type DriftReport struct {
+	WindowFrom time.Time              `json:"window_from"`
+	WindowTo   time.Time              `json:"window_to"`
+	SampleSize int                    `json:"sample_size"`
+	Reference  DriftReferenceResponse `json:"reference"`
+	Thresholds DriftThresholds        `json:"thresholds"`
+	Alerts     int                    `json:"alerts"`
+	Prediction DriftItem              `json:"prediction"`
+	Features   []DriftItem            `json:"features"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// Статусы зависимостей и сервиса
+const (
+	DependencyUp   = "up"
+	DependencyDown = "down"
+
+	StatusAlive    = "alive"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+type LivenessResponse struct {
+	Status  string `json:"status" enums:"alive"`
+	Version string `json:"version"`
+}
+
+// DependencyStatus результат проверки одной зависимости
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status" enums:"up,down"`
+	Required  bool    `json:"required"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessResponse not_ready, если недоступна хотя бы одна обязательная зависимость
+type ReadinessResponse struct {
+	Status       string             `json:"status" enums:"ready,not_ready"`
+	Version      string             `json:"version"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
@@ -1,9 +1,13 @@
 package dto
 
 type MLScoringResponse struct {
-	Prediction  float64            `json:"prediction"`
-	Explanation map[string]map[string]float64 `json:"explanation"`
-	ID          string             `json:"id"`
+	Prediction      float64                       `json:"prediction"`
+	Explanation     map[string]map[string]float64 `json:"explanation"`
+	ID              string                        `json:"id"`
+	ModelVersion    string                        `json:"model_version"`
+	PipelineVersion string                        `json:"pipeline_version"`
+	// Fallback прогноз посчитан резервной моделью из-за недоступности ML-сервиса
+	Fallback bool `json:"-"`
 }
 
 type CreditLimitInput struct {
@@ -16,6 +20,42 @@ type CreditLimitInput struct {
 }
 
 type CreditLimitResult struct {
-	LimitLegal                float64 `json:"limit_legal"`
-	RecommendationCreditLimit float64 `json:"recommendation_credit_limit"`
+	LimitLegal                float64               `json:"limit_legal"`
+	RecommendationCreditLimit float64               `json:"recommendation_credit_limit"`
+	PolicyVersion             string                `json:"policy_version"`
+	Breakdown                 *CreditLimitBreakdown `json:"breakdown"`
+}
+
+// Источник знаменателя при переводе месячного платежа в лимит
+const (
+	DenominatorTurnover = "turnover_ratio"
+	DenominatorFallback = "fallback"
+)
+
+// Причины обнуления лимита
+const (
+	ZeroReasonBlacklist = "blacklist"
+	ZeroReasonOverdue   = "overdue"
+)
+
+// CreditLimitBreakdown промежуточные значения расчета лимита для аудита
+type CreditLimitBreakdown struct {
+	PolicyVersion        string   `json:"policy_version"`
+	PredictedIncome      float64  `json:"predicted_income"`
+	ActiveCCMaxLimit     float64  `json:"active_cc_max_limit"`
+	OutstandSum          float64  `json:"outstand_sum"`
+	AmortizationMonths   int      `json:"amortization_months"`
+	ExistingPayment      float64  `json:"existing_payment"`
+	LegalPDN             float64  `json:"legal_pdn"`
+	BankPDN              float64  `json:"bank_pdn"`
+	LegalMonthlyHeadroom float64  `json:"legal_monthly_headroom"`
+	BankMonthlyHeadroom  float64  `json:"bank_monthly_headroom"`
+	Denominator          float64  `json:"denominator"`
+	DenominatorSource    string   `json:"denominator_source"`
+	LegalLimitBeforeRule float64  `json:"legal_limit_before_rules"`
+	BankLimitBeforeRule  float64  `json:"bank_limit_before_rules"`
+	OverdueSum           float64  `json:"overdue_sum"`
+	BlacklistFlag        int      `json:"blacklist_flag"`
+	Zeroed               bool     `json:"zeroed"`
+	ZeroedReasons        []string `json:"zeroed_reasons"`
 }
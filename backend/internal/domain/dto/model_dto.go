@@ -0,0 +1,41 @@
+package dto
+
+type ModelVariantResponse struct {
+	Name            string  `json:"name"`
+	ModelVersion    string  `json:"model_version"`
+	PipelineVersion string  `json:"pipeline_version"`
+	Shadow          bool    `json:"shadow"`
+	TrafficShare    float64 `json:"traffic_share"`
+}
+
+// VariantTraffic скоринги, посчитанные боевым вариантом за период
+type VariantTraffic struct {
+	Variant          string  `json:"variant"`
+	Scorings         int64   `json:"scorings"`
+	AvgPredictIncome float64 `json:"avg_predict_income"`
+	AvgCreditLimit   float64 `json:"avg_credit_limit"`
+}
+
+// ShadowComparison расхождение теневого варианта (challenger) с боевым (champion)
+// на одних и тех же скорингах. Разницы считаются как challenger - champion.
+type ShadowComparison struct {
+	Champion          string  `json:"champion"`
+	Challenger        string  `json:"challenger"`
+	Pairs             int64   `json:"pairs"`
+	AgreementRate     float64 `json:"agreement_rate"`
+	MeanIncomeDiff    float64 `json:"mean_income_diff"`
+	MeanAbsIncomeDiff float64 `json:"mean_abs_income_diff"`
+	MeanLimitDiff     float64 `json:"mean_limit_diff"`
+	MeanAbsLimitDiff  float64 `json:"mean_abs_limit_diff"`
+	HigherLimitRate   float64 `json:"higher_limit_rate"`
+	LowerLimitRate    float64 `json:"lower_limit_rate"`
+}
+
+type ModelComparisonResponse struct {
+	From               string                 `json:"from"`
+	To                 string                 `json:"to"`
+	AgreementTolerance float64                `json:"agreement_tolerance"`
+	Variants           []ModelVariantResponse `json:"variants"`
+	Traffic            []VariantTraffic       `json:"traffic"`
+	Comparisons        []ShadowComparison     `json:"comparisons"`
+}
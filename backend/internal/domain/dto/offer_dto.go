@@ -0,0 +1,43 @@
+package dto
+
+// MaxOfferTerm предельный срок кредита в месяцах (50 лет). Ограничивает график
+// платежей и при тарифах без верхней границы срока.
+const MaxOfferTerm = 600
+
+type OfferRequest struct {
+	Amount  float64 `json:"amount"`
+	Term    int     `json:"term"`
+	Product string  `json:"product,omitempty"`
+}
+
+type ScheduleItem struct {
+	Month     int     `json:"month"`
+	Payment   float64 `json:"payment"`
+	Principal float64 `json:"principal"`
+	Interest  float64 `json:"interest"`
+	Balance   float64 `json:"balance"`
+}
+
+type LoanOffer struct {
+	Product               string         `json:"product"`
+	Name                  string         `json:"name"`
+	AnnualRate            float64        `json:"annual_rate"`
+	MonthlyPayment        float64        `json:"monthly_payment"`
+	TotalPayment          float64        `json:"total_payment"`
+	TotalInterest         float64        `json:"total_interest"`
+	PDN                   float64        `json:"pdn"`
+	PDNExceedsLimit       bool           `json:"pdn_exceeds_limit"`
+	AboveRecommendedLimit bool           `json:"above_recommended_limit"`
+	Schedule              []ScheduleItem `json:"schedule"`
+}
+
+type OffersResponse struct {
+	ClientID        int64       `json:"client_id"`
+	Amount          float64     `json:"amount"`
+	Term            int         `json:"term"`
+	PredictIncome   float64     `json:"predict_income"`
+	CreditLimit     float64     `json:"credit_limit"`
+	MaxCreditLimit  float64     `json:"max_credit_limit"`
+	ExistingPayment float64     `json:"existing_payment"`
+	Offers          []LoanOffer `json:"offers"`
+}
@@ -0,0 +1,317 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// PromoBandRequest диапазон дохода. ID указывается при обновлении категории,
+// чтобы сохранить диапазон (и id рекомендаций band-<id>); без ID диапазон создается
+type PromoBandRequest struct {
+	ID          int64  `json:"id,omitempty"`
+	MinIncome   int64  `json:"min_income"`
+	MaxIncome   int64  `json:"max_income"`
+	Promo       string `json:"promo"`
+	Description string `json:"description,omitempty"`
+	CTAURL      string `json:"cta_url,omitempty"`
+}
+
+type PromoCategoryRequest struct {
+	Code      string             `json:"code" validate:"required,max=50"`
+	Name      string             `json:"name" validate:"required,max=100"`
+	Kind      string             `json:"kind,omitempty" validate:"omitempty,oneof=promo card investment"`
+	SortOrder int                `json:"sort_order"`
+	Bands     []PromoBandRequest `json:"bands"`
+}
+
+type PromoBandResponse struct {
+	ID          int64  `json:"id"`
+	MinIncome   int64  `json:"min_income"`
+	MaxIncome   int64  `json:"max_income"`
+	Promo       string `json:"promo"`
+	Description string `json:"description,omitempty"`
+	CTAURL      string `json:"cta_url,omitempty"`
+}
+
+type PromoCategoryResponse struct {
+	ID        int64               `json:"id"`
+	Code      string              `json:"code"`
+	Name      string              `json:"name"`
+	Kind      string              `json:"kind"`
+	SortOrder int                 `json:"sort_order"`
+	Bands     []PromoBandResponse `json:"bands"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+func (r *PromoCategoryRequest) ToModel() *models.PromoCategory {
+	kind := r.Kind
+	if kind == "" {
+		kind = models.PromoKindPromo
+	}
+
+	category := &models.PromoCategory{
+		Code:      r.Code,
+		Name:      r.Name,
+		Kind:      kind,
+		SortOrder: r.SortOrder,
+		Bands:     make([]models.PromoBand, 0, len(r.Bands)),
+	}
+	for _, band := range r.Bands {
+		category.Bands = append(category.Bands, models.PromoBand{
+			ID:          band.ID,
+			MinIncome:   band.MinIncome,
+			MaxIncome:   band.MaxIncome,
+			Promo:       band.Promo,
+			Description: band.Description,
+			CTAURL:      band.CTAURL,
+		})
+	}
+	return category
+}
+
+func FromPromoCategory(category *models.PromoCategory) *PromoCategoryResponse {
+	response := &PromoCategoryResponse{
+		ID:        category.ID,
+		Code:      category.Code,
+		Name:      category.Name,
+		Kind:      category.Kind,
+		SortOrder: category.SortOrder,
+		Bands:     make([]PromoBandResponse, 0, len(category.Bands)),
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+	for _, band := range category.Bands {
+		response.Bands = append(response.Bands, PromoBandResponse{
+			ID:          band.ID,
+			MinIncome:   band.MinIncome,
+			MaxIncome:   band.MaxIncome,
+			Promo:       band.Promo,
+			Description: band.Description,
+			CTAURL:      band.CTAURL,
+		})
+	}
+	return response
+}
+
+func FromPromoCategories(categories []models.PromoCategory) []*PromoCategoryResponse {
+	responses := make([]*PromoCategoryResponse, 0, len(categories))
+	for i := range categories {
+		responses = append(responses, FromPromoCategory(&categories[i]))
+	}
+	return responses
+}
+
+// Recommendation персональное предложение клиенту. ID стабилен для одного
+// предложения каталога: band-<id> для диапазона дохода, campaign-<id> для кампании.
+type Recommendation struct {
+	ID                string `json:"id"`
+	Category          string `json:"category" enums:"promo,card,investment"`
+	Title             string `json:"title"`
+	Description       string `json:"description"`
+	Priority          int    `json:"priority"`
+	EligibilityReason string `json:"eligibility_reason"`
+	CTAURL            string `json:"cta_url,omitempty"`
+}
+
+// Переменные таргетинга, которые вычисляются из клиента и результата скоринга
+const (
+	TargetClientAge     = "client_age"
+	TargetPredictIncome = "predict_income"
+	TargetIncome        = "income"
+)
+
+// PromoRequest данные клиента и скоринга для подбора промо
+type PromoRequest struct {
+	ClientID      int64
+	Income        int64
+	PredictIncome float64
+	Age           int
+	Features      map[string]interface{}
+	// Preview - подбор без учета выдачи в лимитах кампаний (симуляция, заявители)
+	Preview bool
+	Now     time.Time
+}
+
+// Variables признаки клиента вместе с вычисляемыми переменными для условий таргетинга
+func (r *PromoRequest) Variables() map[string]interface{} {
+	values := make(map[string]interface{}, len(r.Features)+3)
+	for k, v := range r.Features {
+		values[k] = v
+	}
+	values[TargetClientAge] = float64(r.Age)
+	values[TargetPredictIncome] = r.PredictIncome
+	values[TargetIncome] = float64(r.Income)
+	return values
+}
+
+type PromoCampaignRequest struct {
+	CategoryID  int64                  `json:"category_id" validate:"required"`
+	Name        string                 `json:"name" validate:"required,max=200"`
+	Promo       string                 `json:"promo" validate:"required"`
+	Description string                 `json:"description,omitempty"`
+	CTAURL      string                 `json:"cta_url,omitempty" validate:"omitempty,url,max=500"`
+	StartsAt    time.Time              `json:"starts_at" validate:"required"`
+	EndsAt      *time.Time             `json:"ends_at,omitempty"`
+	Priority    int                    `json:"priority"`
+	MaxIssued   int64                  `json:"max_issued"`
+	Enabled     *bool                  `json:"enabled,omitempty"`
+	Targeting   []models.TargetingRule `json:"targeting"`
+}
+
+type PromoCampaignResponse struct {
+	ID          int64                  `json:"id"`
+	CategoryID  int64                  `json:"category_id"`
+	Name        string                 `json:"name"`
+	Promo       string                 `json:"promo"`
+	Description string                 `json:"description,omitempty"`
+	CTAURL      string                 `json:"cta_url,omitempty"`
+	StartsAt    time.Time              `json:"starts_at"`
+	EndsAt      *time.Time             `json:"ends_at,omitempty"`
+	Priority    int                    `json:"priority"`
+	MaxIssued   int64                  `json:"max_issued"`
+	IssuedCount int64                  `json:"issued_count"`
+	Enabled     bool                   `json:"enabled"`
+	Targeting   []models.TargetingRule `json:"targeting"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+func (r *PromoCampaignRequest) ToModel() (*models.PromoCampaign, error) {
+	targeting := r.Targeting
+	if targeting == nil {
+		targeting = []models.TargetingRule{}
+	}
+	targetingJSON, err := json.Marshal(targeting)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal targeting: %w", err)
+	}
+
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+
+	return &models.PromoCampaign{
+		CategoryID:  r.CategoryID,
+		Name:        r.Name,
+		Promo:       r.Promo,
+		Description: r.Description,
+		CTAURL:      r.CTAURL,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt,
+		Priority:    r.Priority,
+		MaxIssued:   r.MaxIssued,
+		Enabled:     enabled,
+		Targeting:   targetingJSON,
+	}, nil
+}
+
+func FromPromoCampaign(campaign *models.PromoCampaign) (*PromoCampaignResponse, error) {
+	rules, err := campaign.Rules()
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []models.TargetingRule{}
+	}
+
+	return &PromoCampaignResponse{
+		ID:          campaign.ID,
+		CategoryID:  campaign.CategoryID,
+		Name:        campaign.Name,
+		Promo:       campaign.Promo,
+		Description: campaign.Description,
+		CTAURL:      campaign.CTAURL,
+		StartsAt:    campaign.StartsAt,
+		EndsAt:      campaign.EndsAt,
+		Priority:    campaign.Priority,
+		MaxIssued:   campaign.MaxIssued,
+		IssuedCount: campaign.IssuedCount,
+		Enabled:     campaign.Enabled,
+		Targeting:   rules,
+		CreatedAt:   campaign.CreatedAt,
+		UpdatedAt:   campaign.UpdatedAt,
+	}, nil
+}
+
+func FromPromoCampaigns(campaigns []models.PromoCampaign) ([]*PromoCampaignResponse, error) {
+	responses := make([]*PromoCampaignResponse, 0, len(campaigns))
+	for i := range campaigns {
+		response, err := FromPromoCampaign(&campaigns[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+type PromoImpressionResponse struct {
+	ID          int64      `json:"id"`
+	ClientID    int64      `json:"client_id"`
+	ScoringID   int64      `json:"scoring_id"`
+	PromoID     string     `json:"promo_id"`
+	Category    string     `json:"category"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status" enums:"shown,accepted,declined"`
+	ShownAt     time.Time  `json:"shown_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+func FromPromoImpression(impression *models.PromoImpression) *PromoImpressionResponse {
+	return &PromoImpressionResponse{
+		ID:          impression.ID,
+		ClientID:    impression.ClientID,
+		ScoringID:   impression.ScoringID,
+		PromoID:     impression.PromoID,
+		Category:    impression.Category,
+		Title:       impression.Title,
+		Status:      impression.Status,
+		ShownAt:     impression.CreatedAt,
+		RespondedAt: impression.RespondedAt,
+	}
+}
+
+// PromoConversion счетчики показов и конверсия accepted / impressions
+type PromoConversion struct {
+	Impressions    int64   `json:"impressions"`
+	Accepted       int64   `json:"accepted"`
+	Declined       int64   `json:"declined"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+// Add добавляет счетчики и пересчитывает конверсию
+func (c *PromoConversion) Add(impressions, accepted, declined int64) {
+	c.Impressions += impressions
+	c.Accepted += accepted
+	c.Declined += declined
+	if c.Impressions > 0 {
+		c.ConversionRate = float64(c.Accepted) / float64(c.Impressions)
+	}
+}
+
+// PromoIncomeBandStats конверсия промо в диапазоне прогноза дохода [min_income, max_income).
+// У последнего диапазона нет max_income.
+type PromoIncomeBandStats struct {
+	MinIncome float64  `json:"min_income"`
+	MaxIncome *float64 `json:"max_income,omitempty"`
+	PromoConversion
+}
+
+type PromoStats struct {
+	PromoID  string `json:"promo_id"`
+	Category string `json:"category"`
+	Title    string `json:"title"`
+	PromoConversion
+	IncomeBands []PromoIncomeBandStats `json:"income_bands"`
+}
+
+type PromoStatsResponse struct {
+	From  string        `json:"from"`
+	To    string        `json:"to"`
+	Items []*PromoStats `json:"items"`
+}
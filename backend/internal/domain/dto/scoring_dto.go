@@ -1,16 +1,304 @@
 package dto
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// Решение по заявке
+const (
+	DecisionApproved = "approved"
+	DecisionDeclined = "declined"
+)
+
+// DeclineReason сработавшее правило жесткого отказа
+type DeclineReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Factor признак, повлиявший на прогноз: значение у клиента и вклад в прогноз (SHAP)
+type Factor struct {
+	Feature      string      `json:"feature"`
+	LabelRu      string      `json:"label_ru"`
+	Value        interface{} `json:"value"`
+	Contribution float64     `json:"contribution"`
+}
+
+// FactorCategory суммарный вклад группы признаков в прогноз. Share - доля
+// со знаком от суммы модулей вкладов всех признаков
+type FactorCategory struct {
+	Category     string  `json:"category"`
+	Contribution float64 `json:"contribution"`
+	Share        float64 `json:"share"`
+	FeatureCount int     `json:"feature_count"`
+}
+
 type ScoringResponse struct {
-	Id                        int64    `json:"id"`
-	FirstName                 string   `json:"first_name"`
-	LastName                  string   `json:"last_name"`
-	MiddleName                string   `json:"middle_name,omitempty"`
-	BirthDate                 string   `json:"birth_date"`
-	Income                    int64    `json:"income,omitempty"`
-	PredictIncome             float64  `json:"predict_income"`
-	RecommendationCreditLimit float64  `json:"credit_limit"`
-	MaxCreditLimit            float64  `json:"max_credit_limit,omitempty"`
-	Recommendations           []string `json:"recommendations"`
-	PositiveFactors           []string `json:"positive_factors"`
-	NegativeFactors           []string `json:"negative_factors"`
+	Id                        int64            `json:"id"`
+	FirstName                 string           `json:"first_name"`
+	LastName                  string           `json:"last_name"`
+	MiddleName                string           `json:"middle_name,omitempty"`
+	BirthDate                 string           `json:"birth_date"`
+	Income                    int64            `json:"income,omitempty"`
+	PredictIncome             float64          `json:"predict_income"`
+	RecommendationCreditLimit float64          `json:"credit_limit"`
+	MaxCreditLimit            float64          `json:"max_credit_limit,omitempty"`
+	Recommendations           []Recommendation `json:"recommendations"`
+	PositiveFactors           []Factor         `json:"positive_factors"`
+	NegativeFactors           []Factor         `json:"negative_factors"`
+	FactorCategories          []FactorCategory `json:"factor_categories"`
+
+	DataCompleteness         float64  `json:"data_completeness"`
+	MissingImportantFeatures []string `json:"missing_important_features"`
+	InsufficientData         bool     `json:"insufficient_data"`
+
+	Decision       string          `json:"decision"`
+	DeclineReasons []DeclineReason `json:"decline_reasons"`
+
+	PolicyVersion string                `json:"policy_version"`
+	Breakdown     *CreditLimitBreakdown `json:"breakdown,omitempty"`
+
+	// ModelVariant вариант модели champion/challenger, которым посчитан прогноз
+	ModelVariant string `json:"model_variant"`
+
+	// Cached прогноз взят из кэша, ScoredAt - время его расчета в ML
+	Cached   bool      `json:"cached"`
+	ScoredAt time.Time `json:"scored_at"`
+
+	// Stale ML недоступен и прогноз взят из последнего сохраненного скоринга
+	// клиента; StaleAgeSeconds - его возраст. Лимит и промо посчитаны заново.
+	Stale           bool  `json:"stale"`
+	StaleAgeSeconds int64 `json:"stale_age_seconds,omitempty"`
+}
+
+type ScoringRecordResponse struct {
+	ID              int64            `json:"id"`
+	ClientID        int64            `json:"client_id"`
+	PredictIncome   float64          `json:"predict_income"`
+	CreditLimit     float64          `json:"credit_limit"`
+	MaxCreditLimit  float64          `json:"max_credit_limit"`
+	Recommendations []Recommendation `json:"recommendations"`
+	PositiveFactors []Factor         `json:"positive_factors"`
+	NegativeFactors []Factor         `json:"negative_factors"`
+	ModelVersion    string           `json:"model_version"`
+	PipelineVersion string           `json:"pipeline_version"`
+	MLUID           string           `json:"ml_uid,omitempty"`
+	ModelVariant    string           `json:"model_variant,omitempty"`
+
+	// FactorCategories вклад по категориям признаков; пуст в записях до появления категорий
+	FactorCategories []FactorCategory `json:"factor_categories"`
+
+	DataCompleteness         float64  `json:"data_completeness"`
+	InsufficientData         bool     `json:"insufficient_data"`
+	ImputedFeatures          []string `json:"imputed_features"`
+	MissingImportantFeatures []string `json:"missing_important_features"`
+
+	Decision       string          `json:"decision"`
+	DeclineReasons []DeclineReason `json:"decline_reasons"`
+
+	PolicyVersion string                `json:"policy_version"`
+	Breakdown     *CreditLimitBreakdown `json:"breakdown,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScoringHistoryResponse struct {
+	Items  []*ScoringRecordResponse `json:"items"`
+	Total  int64                    `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+func FromScoringRecord(record *models.ScoringRecord) (*ScoringRecordResponse, error) {
+	response := &ScoringRecordResponse{
+		ID:              record.ID,
+		ClientID:        record.ClientID,
+		PredictIncome:   record.PredictIncome,
+		CreditLimit:     record.CreditLimit,
+		MaxCreditLimit:  record.MaxCreditLimit,
+		Recommendations: []Recommendation{},
+		PositiveFactors: []Factor{},
+		NegativeFactors: []Factor{},
+		ModelVersion:    record.ModelVersion,
+		PipelineVersion: record.PipelineVersion,
+		MLUID:           record.MLUID,
+		ModelVariant:    record.ModelVariant,
+
+		FactorCategories: []FactorCategory{},
+
+		DataCompleteness:         record.DataCompleteness,
+		InsufficientData:         record.InsufficientData,
+		ImputedFeatures:          []string{},
+		MissingImportantFeatures: []string{},
+
+		Decision:       record.Decision,
+		DeclineReasons: []DeclineReason{},
+
+		PolicyVersion: record.PolicyVersion,
+
+		CreatedAt: record.CreatedAt,
+	}
+
+	if err := unmarshalRecommendations(record.Recommendations, &response.Recommendations); err != nil {
+		return nil, fmt.Errorf("failed to decode recommendations: %w", err)
+	}
+	if err := unmarshalFactors(record.PositiveFactors, &response.PositiveFactors); err != nil {
+		return nil, fmt.Errorf("failed to decode positive factors: %w", err)
+	}
+	if err := unmarshalFactors(record.NegativeFactors, &response.NegativeFactors); err != nil {
+		return nil, fmt.Errorf("failed to decode negative factors: %w", err)
+	}
+	if len(record.FactorCategories) > 0 {
+		if err := json.Unmarshal(record.FactorCategories, &response.FactorCategories); err != nil {
+			return nil, fmt.Errorf("failed to decode factor categories: %w", err)
+		}
+	}
+	if err := unmarshalStrings(record.ImputedFeatures, &response.ImputedFeatures); err != nil {
+		return nil, fmt.Errorf("failed to decode imputed features: %w", err)
+	}
+	if err := unmarshalStrings(record.MissingImportantFeatures, &response.MissingImportantFeatures); err != nil {
+		return nil, fmt.Errorf("failed to decode missing important features: %w", err)
+	}
+	if len(record.DeclineReasons) > 0 {
+		if err := json.Unmarshal(record.DeclineReasons, &response.DeclineReasons); err != nil {
+			return nil, fmt.Errorf("failed to decode decline reasons: %w", err)
+		}
+	}
+	if len(record.CreditBreakdown) > 0 {
+		if err := json.Unmarshal(record.CreditBreakdown, &response.Breakdown); err != nil {
+			return nil, fmt.Errorf("failed to decode credit limit breakdown: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+func FromScoringRecords(records []models.ScoringRecord) ([]*ScoringRecordResponse, error) {
+	responses := make([]*ScoringRecordResponse, 0, len(records))
+	for _, record := range records {
+		response, err := FromScoringRecord(&record)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func unmarshalStrings(data []byte, target *[]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// unmarshalRecommendations читает рекомендации скоринга. Ранние записи хранят
+// рекомендации строками - они возвращаются как рекомендации с одним заголовком.
+func unmarshalRecommendations(data []byte, target *[]Recommendation) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, target); err == nil {
+		return nil
+	}
+
+	var titles []string
+	if err := json.Unmarshal(data, &titles); err != nil {
+		return err
+	}
+	recommendations := make([]Recommendation, 0, len(titles))
+	for i, title := range titles {
+		recommendations = append(recommendations, Recommendation{Title: title, Priority: i + 1})
+	}
+	*target = recommendations
+	return nil
+}
+
+// unmarshalFactors читает факторы скоринга. Ранние записи хранят только имена
+// признаков - они возвращаются факторами без подписи, значения и вклада.
+func unmarshalFactors(data []byte, target *[]Factor) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, target); err == nil {
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	factors := make([]Factor, 0, len(names))
+	for _, name := range names {
+		factors = append(factors, Factor{Feature: name})
+	}
+	*target = factors
+	return nil
+}
+
+type BatchScoringRequest struct {
+	ClientIDs []int64       `json:"client_ids,omitempty"`
+	Filter    *SearchParams `json:"filter,omitempty"`
+}
+
+// Коды ошибок пакетного скоринга
+const (
+	BatchErrorNotFound   = "not_found"
+	BatchErrorNoFeatures = "no_features"
+	BatchErrorNoData     = "insufficient_data"
+	BatchErrorMLFailure  = "ml_failure"
+	BatchErrorMLDown     = "ml_unavailable"
+	BatchErrorInternal   = "internal_error"
+)
+
+type BatchScoringError struct {
+	ClientID int64  `json:"client_id"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+type BatchScoringResponse struct {
+	Total        int                 `json:"total"`
+	SuccessCount int                 `json:"success_count"`
+	FailureCount int                 `json:"failure_count"`
+	Results      []*ScoringResponse  `json:"results"`
+	Errors       []BatchScoringError `json:"errors"`
+}
+
+// ApplicantScoringRequest данные заявителя, который еще не заведен как клиент
+type ApplicantScoringRequest struct {
+	FirstName  string                 `json:"first_name" validate:"required,min=1,max=100"`
+	LastName   string                 `json:"last_name" validate:"required,min=1,max=100"`
+	MiddleName string                 `json:"middle_name" validate:"omitempty,max=100"`
+	BirthDate  string                 `json:"birth_date" validate:"required,datetime=02-01-2006"`
+	Features   map[string]interface{} `json:"features"`
+}
+
+// ToModel собирает несохраняемую модель клиента для расчета скоринга
+func (r *ApplicantScoringRequest) ToModel() (*models.Client, error) {
+	return (*CreateClientRequest)(r).ToModel()
+}
+
+// SimulationRequest переопределения признаков для what-if расчета.
+// Значение null сбрасывает признак к значению по умолчанию из схемы.
+type SimulationRequest struct {
+	Overrides map[string]interface{} `json:"overrides"`
+}
+
+type ScoringDelta struct {
+	PredictIncome  float64 `json:"predict_income"`
+	CreditLimit    float64 `json:"credit_limit"`
+	MaxCreditLimit float64 `json:"max_credit_limit"`
+}
+
+type SimulationResponse struct {
+	ClientID  int64                  `json:"client_id"`
+	Overrides map[string]interface{} `json:"overrides"`
+	Baseline  *ScoringResponse       `json:"baseline"`
+	Simulated *ScoringResponse       `json:"simulated"`
+	Delta     ScoringDelta           `json:"delta"`
 }
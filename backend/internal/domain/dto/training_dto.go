@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+type IncomeConfirmationRequest struct {
+	Income      float64 `json:"income" validate:"required,gt=0"`
+	Source      string  `json:"source" validate:"required,oneof=payslip bank_statement tax_return salary_project other" enums:"payslip,bank_statement,tax_return,salary_project,other"`
+	ConfirmedAt string  `json:"confirmed_at" validate:"required,datetime=02-01-2006" example:"15-09-2024"`
+}
+
+// IncomeConfirmationResponse подтвержденный доход. training_sample_id нет, если у клиента
+// нет сохраненного скоринга с признаками - тогда пример для дообучения не создается.
+type IncomeConfirmationResponse struct {
+	ID               int64     `json:"id"`
+	ClientID         int64     `json:"client_id"`
+	Income           float64   `json:"income"`
+	Source           string    `json:"source"`
+	ConfirmedAt      string    `json:"confirmed_at"`
+	ScoringID        *int64    `json:"scoring_id,omitempty"`
+	TrainingSampleID *int64    `json:"training_sample_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func FromIncomeConfirmation(confirmation *models.IncomeConfirmation, sample *models.TrainingSample) *IncomeConfirmationResponse {
+	response := &IncomeConfirmationResponse{
+		ID:          confirmation.ID,
+		ClientID:    confirmation.ClientID,
+		Income:      confirmation.Income,
+		Source:      confirmation.Source,
+		ConfirmedAt: confirmation.ConfirmedAt.Format(DateFormat),
+		CreatedAt:   confirmation.CreatedAt,
+	}
+	if sample != nil {
+		response.ScoringID = &sample.ScoringID
+		response.TrainingSampleID = &sample.ID
+	}
+	return response
+}
+
+// TrainingRow размеченный пример в запросе к /api/train
+type TrainingRow struct {
+	SampleID        int64           `json:"sample_id"`
+	ClientID        int64           `json:"client_id"`
+	Features        json.RawMessage `json:"features"`
+	Target          float64         `json:"target"`
+	PredictIncome   float64         `json:"predict_income"`
+	ModelVersion    string          `json:"model_version"`
+	PipelineVersion string          `json:"pipeline_version"`
+}
+
+// TrainingBatch пакет примеров для ML-сервиса. При повторной отправке пример может
+// прийти в другом пакете; sample_id уникален и позволяет отбросить дубликаты.
+type TrainingBatch struct {
+	BatchID string        `json:"batch_id"`
+	Samples []TrainingRow `json:"samples"`
+}
+
+type TrainingDeliveryStatsResponse struct {
+	Pending    int64      `json:"pending"`
+	Sending    int64      `json:"sending"`
+	Sent       int64      `json:"sent"`
+	Failed     int64      `json:"failed"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
+
+// QualityMetrics ошибки прогноза относительно подтвержденного дохода:
+// mae - средняя абсолютная ошибка, mape - средняя абсолютная ошибка в процентах,
+// bias - средняя ошибка прогноз - факт (больше нуля - модель завышает доход)
+type QualityMetrics struct {
+	Count int     `json:"count"`
+	MAE   float64 `json:"mae"`
+	MAPE  float64 `json:"mape"`
+	Bias  float64 `json:"bias"`
+}
+
+type QualityGroup struct {
+	Group string `json:"group"`
+	QualityMetrics
+}
+
+type ModelQualityResponse struct {
+	From           string         `json:"from"`
+	To             string         `json:"to"`
+	Overall        QualityMetrics `json:"overall"`
+	ByModelVersion []QualityGroup `json:"by_model_version"`
+	ByIncomeBand   []QualityGroup `json:"by_income_band"`
+	ByRegion       []QualityGroup `json:"by_region"`
+	ByAgeGroup     []QualityGroup `json:"by_age_group"`
+}
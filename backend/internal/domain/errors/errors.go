@@ -1,6 +1,10 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+	"time"
+)
 
 // Ошибки репозитория клиентов
 var (
@@ -11,6 +15,42 @@ var (
 	ErrClientAlreadyExists = errors.New("client already exists")
 )
 
+// Ошибки скоринга
+var (
+	ErrScoringNotFound = errors.New("scoring result not found")
+
+	ErrNoFeatures = errors.New("no features available for client")
+
+	ErrInsufficientData = errors.New("insufficient data for scoring")
+)
+
+// Ошибки каталога промо
+var (
+	ErrPromoNotFound = errors.New("promo category not found")
+
+	ErrPromoAlreadyExists = errors.New("promo category already exists")
+
+	ErrCampaignNotFound = errors.New("promo campaign not found")
+
+	ErrImpressionNotFound = errors.New("promo impression not found")
+
+	ErrOutcomeAlreadyRecorded = errors.New("promo outcome already recorded")
+)
+
+// Ошибки мониторинга дрейфа
+var (
+	ErrDriftReferenceNotFound = errors.New("drift reference is not built")
+
+	ErrNoScoringSnapshots = errors.New("no scoring snapshots in window")
+)
+
+// Ошибки кредитных предложений
+var (
+	ErrAmountExceedsLimit = errors.New("requested amount exceeds credit limit")
+
+	ErrNoMatchingRate = errors.New("no product rate for requested amount and term")
+)
+
 // Ошибки валидации
 var (
 	ErrInvalidInput = errors.New("invalid input data")
@@ -20,6 +60,35 @@ var (
 	ErrInvalidFormat = errors.New("invalid data format")
 )
 
+// FieldError ошибка валидации одного поля запроса
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// ValidationError ошибка валидации с перечнем полей; Err — базовая ошибка
+// (например, ErrInvalidFeatures) для errors.Is
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+	if len(messages) == 0 {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
 // Ошибки ML сервиса
 var (
 	ErrMLServiceUnavailable = errors.New("ML service is unavailable")
@@ -29,6 +98,21 @@ var (
 	ErrInvalidFeatures = errors.New("invalid features for ML model")
 )
 
+// RetryAfterError ошибка временной недоступности с подсказкой,
+// через сколько имеет смысл повторить запрос
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 // Ошибки базы данных
 var (
 	ErrDatabaseConnection = errors.New("database connection error")
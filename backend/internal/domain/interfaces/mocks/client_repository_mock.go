@@ -9,12 +9,13 @@ import (
 
 // MockClientRepository мок репозитория клиентов для тестов
 type MockClientRepository struct {
-	CreateFunc  func(ctx context.Context, client *models.Client) error
-	GetByIDFunc func(ctx context.Context, id int64) (*models.Client, error)
-	SearchFunc  func(ctx context.Context, params dto.SearchParams) ([]models.Client, error)
-	UpdateFunc  func(ctx context.Context, client *models.Client) error
-	DeleteFunc  func(ctx context.Context, id int64) error
-	ListFunc    func(ctx context.Context, limit, offset int) ([]models.Client, error)
+	CreateFunc      func(ctx context.Context, client *models.Client) error
+	BatchCreateFunc func(ctx context.Context, clients []*models.Client) (int, error)
+	GetByIDFunc     func(ctx context.Context, id int64) (*models.Client, error)
+	SearchFunc      func(ctx context.Context, params dto.SearchParams, limit int) ([]models.Client, error)
+	UpdateFunc      func(ctx context.Context, client *models.Client) error
+	DeleteFunc      func(ctx context.Context, id int64) error
+	ListFunc        func(ctx context.Context, limit, offset int) ([]models.Client, error)
 }
 
 func (m *MockClientRepository) Create(ctx context.Context, client *models.Client) error {
@@ -24,6 +25,13 @@ func (m *MockClientRepository) Create(ctx context.Context, client *models.Client
 	return nil
 }
 
+func (m *MockClientRepository) BatchCreate(ctx context.Context, clients []*models.Client) (int, error) {
+	if m.BatchCreateFunc != nil {
+		return m.BatchCreateFunc(ctx, clients)
+	}
+	return len(clients), nil
+}
+
 func (m *MockClientRepository) GetByID(ctx context.Context, id int64) (*models.Client, error) {
 	if m.GetByIDFunc != nil {
 		return m.GetByIDFunc(ctx, id)
@@ -31,9 +39,9 @@ func (m *MockClientRepository) GetByID(ctx context.Context, id int64) (*models.C
 	return nil, nil
 }
 
-func (m *MockClientRepository) Search(ctx context.Context, params dto.SearchParams) ([]models.Client, error) {
+func (m *MockClientRepository) Search(ctx context.Context, params dto.SearchParams, limit int) ([]models.Client, error) {
 	if m.SearchFunc != nil {
-		return m.SearchFunc(ctx, params)
+		return m.SearchFunc(ctx, params, limit)
 	}
 	return []models.Client{}, nil
 }
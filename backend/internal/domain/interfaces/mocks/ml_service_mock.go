@@ -3,20 +3,29 @@ package mocks
 import (
 	"context"
 
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
 )
 
 type MockMLService struct {
-	PredictFunc          func(ctx context.Context, features map[string]interface{}) (*models.ScoringResult, error)
-	SendTrainingDataFunc func(ctx context.Context, data interface{}) error
-	HealthCheckFunc      func(ctx context.Context) error
+	PredictFunc                func(ctx context.Context, features map[string]interface{}) (*models.ScoringResult, error)
+	PredictWithExplanationFunc func(ctx context.Context, features map[string]interface{}) (*dto.MLScoringResponse, error)
+	SendTrainingDataFunc       func(ctx context.Context, data interface{}) error
+	HealthCheckFunc            func(ctx context.Context) error
 }
 
 func (m *MockMLService) Predict(ctx context.Context, features map[string]interface{}) (*models.ScoringResult, error) {
 	if m.PredictFunc != nil {
 		return m.PredictFunc(ctx, features)
 	}
-	return &models.ScoringResult{Score: 0.75}, nil
+	return &models.ScoringResult{PredictIncome: 75000}, nil
+}
+
+func (m *MockMLService) PredictWithExplanation(ctx context.Context, features map[string]interface{}) (*dto.MLScoringResponse, error) {
+	if m.PredictWithExplanationFunc != nil {
+		return m.PredictWithExplanationFunc(ctx, features)
+	}
+	return &dto.MLScoringResponse{Prediction: 75000}, nil
 }
 
 func (m *MockMLService) SendTrainingData(ctx context.Context, data interface{}) error {
@@ -1,7 +1,14 @@
 package interfaces
 
-import "context"
+import (
+	"context"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+)
 
 type PromoProvider interface {
-	GetPromos(ctx context.Context, income int64, predictIncome float64) ([]string, error)
+	// GetRecommendations возвращает не больше одного предложения на категорию:
+	// подходящую активную кампанию с наибольшим приоритетом или предложение по диапазону дохода.
+	// Рекомендации упорядочены по priority.
+	GetRecommendations(ctx context.Context, req *dto.PromoRequest) ([]dto.Recommendation, error)
 }
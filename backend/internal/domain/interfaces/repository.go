@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
@@ -14,7 +15,8 @@ type ClientRepository interface {
 
 	GetByID(ctx context.Context, id int64) (*models.Client, error)
 
-	Search(ctx context.Context, params dto.SearchParams) ([]models.Client, error)
+	// Search ищет клиентов по параметрам; limit <= 0 - без ограничения
+	Search(ctx context.Context, params dto.SearchParams, limit int) ([]models.Client, error)
 
 	Update(ctx context.Context, client *models.Client) error
 
@@ -22,3 +24,106 @@ type ClientRepository interface {
 
 	List(ctx context.Context, limit, offset int) ([]models.Client, error)
 }
+
+type ScoringRepository interface {
+	Create(ctx context.Context, record *models.ScoringRecord) error
+
+	GetLatestByClientID(ctx context.Context, clientID int64) (*models.ScoringRecord, error)
+
+	ListByClientID(ctx context.Context, clientID int64, limit, offset int) ([]models.ScoringRecord, int64, error)
+
+	// ListSnapshots возвращает до limit последних скорингов за [from, to)
+	// только с прогнозом, признаками и списком импутированных признаков
+	ListSnapshots(ctx context.Context, from, to time.Time, limit int) ([]models.ScoringRecord, error)
+
+	// VariantStats агрегирует скоринги за [from, to) по вариантам модели
+	VariantStats(ctx context.Context, from, to time.Time) ([]models.ModelVariantStat, error)
+}
+
+type PromoRepository interface {
+	ListCategories(ctx context.Context) ([]models.PromoCategory, error)
+
+	GetCategory(ctx context.Context, id int64) (*models.PromoCategory, error)
+
+	GetCategoryByCode(ctx context.Context, code string) (*models.PromoCategory, error)
+
+	CreateCategory(ctx context.Context, category *models.PromoCategory) error
+
+	// UpdateCategory обновляет категорию и ее диапазоны: диапазоны с ID обновляются,
+	// без ID создаются, отсутствующие в категории удаляются
+	UpdateCategory(ctx context.Context, category *models.PromoCategory) error
+
+	DeleteCategory(ctx context.Context, id int64) error
+
+	// SeedIfEmpty заполняет каталог, если в нем нет ни одной категории
+	SeedIfEmpty(ctx context.Context, categories []models.PromoCategory) (bool, error)
+
+	ListCampaigns(ctx context.Context) ([]models.PromoCampaign, error)
+
+	// ListLiveCampaigns возвращает включенные кампании, которые еще не закончились к now
+	ListLiveCampaigns(ctx context.Context, now time.Time) ([]models.PromoCampaign, error)
+
+	GetCampaign(ctx context.Context, id int64) (*models.PromoCampaign, error)
+
+	CreateCampaign(ctx context.Context, campaign *models.PromoCampaign) error
+
+	UpdateCampaign(ctx context.Context, campaign *models.PromoCampaign) error
+
+	DeleteCampaign(ctx context.Context, id int64) error
+
+	// ReserveCampaign атомарно учитывает выдачу кампании клиенту. Повторная выдача
+	// тому же клиенту лимит не расходует. Возвращает false, если лимит выдач уже исчерпан.
+	ReserveCampaign(ctx context.Context, id, clientID int64) (bool, error)
+}
+
+type PromoImpressionRepository interface {
+	CreateImpressions(ctx context.Context, impressions []models.PromoImpression) error
+
+	// GetLatestImpression возвращает последний показ промо клиенту
+	GetLatestImpression(ctx context.Context, clientID int64, promoID string) (*models.PromoImpression, error)
+
+	// RecordOutcome сохраняет реакцию клиента, если она еще не записана
+	RecordOutcome(ctx context.Context, id int64, status string, at time.Time) (bool, error)
+
+	// Stats агрегирует показы за [from, to) по промо и диапазонам дохода с границами bandBounds
+	Stats(ctx context.Context, from, to time.Time, bandBounds []float64) ([]models.PromoImpressionStat, error)
+}
+
+type TrainingRepository interface {
+	// CreateConfirmation сохраняет подтверждение дохода и, если передан, размеченный пример к нему
+	CreateConfirmation(ctx context.Context, confirmation *models.IncomeConfirmation, sample *models.TrainingSample) error
+
+	// ClaimDue атомарно забирает до limit неотправленных примеров, у которых подошло
+	// время попытки, и переводит их в sending на lease, чтобы другие экземпляры их
+	// не взяли. Если отправка не завершилась за lease, примеры снова считаются готовыми.
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.TrainingSample, error)
+
+	MarkSent(ctx context.Context, ids []int64, batchID string, at time.Time) error
+
+	// MarkFailed увеличивает число попыток и откладывает следующую на backoff * 2^attempts.
+	// После maxAttempts попыток пример получает статус failed.
+	MarkFailed(ctx context.Context, ids []int64, batchID string, reason string, at time.Time, backoff time.Duration, maxAttempts int) error
+
+	CountByStatus(ctx context.Context) ([]models.TrainingDeliveryStat, error)
+
+	LastSentAt(ctx context.Context) (*time.Time, error)
+
+	// ListOutcomes возвращает примеры с датой подтверждения дохода в [from, to);
+	// регион берется из признака regionFeature снимка признаков
+	ListOutcomes(ctx context.Context, from, to time.Time, regionFeature string) ([]models.LabeledOutcome, error)
+}
+
+type DriftRepository interface {
+	// ReplaceReferences заменяет все эталонные распределения
+	ReplaceReferences(ctx context.Context, references []models.DriftReference) error
+
+	ListReferences(ctx context.Context) ([]models.DriftReference, error)
+}
+
+type ShadowPredictionRepository interface {
+	Create(ctx context.Context, prediction *models.ShadowPrediction) error
+
+	// Compare агрегирует расхождения теневых прогнозов с боевыми за [from, to)
+	// по паре вариантов. Прогнозы согласны, если относительная разница не больше tolerance.
+	Compare(ctx context.Context, from, to time.Time, tolerance float64) ([]models.ShadowComparisonStat, error)
+}
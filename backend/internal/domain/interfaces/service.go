@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
@@ -25,6 +26,57 @@ type ClientService interface {
 
 type ScoringService interface {
 	CalculateScoring(ctx context.Context, id int64) (*dto.ScoringResponse, error)
+
+	CalculateBatchScoring(ctx context.Context, req *dto.BatchScoringRequest) (*dto.BatchScoringResponse, error)
+
+	GetScoringHistory(ctx context.Context, clientID int64, limit, offset int) (*dto.ScoringHistoryResponse, error)
+
+	GetLatestScoring(ctx context.Context, clientID int64) (*dto.ScoringRecordResponse, error)
+
+	SimulateScoring(ctx context.Context, clientID int64, req *dto.SimulationRequest) (*dto.SimulationResponse, error)
+
+	ScoreApplicant(ctx context.Context, req *dto.ApplicantScoringRequest) (*dto.ScoringResponse, error)
+}
+
+type OfferService interface {
+	GetOffers(ctx context.Context, clientID int64, req *dto.OfferRequest) (*dto.OffersResponse, error)
+}
+
+type TrainingService interface {
+	ConfirmIncome(ctx context.Context, clientID int64, req *dto.IncomeConfirmationRequest) (*dto.IncomeConfirmationResponse, error)
+
+	GetDeliveryStats(ctx context.Context) (*dto.TrainingDeliveryStatsResponse, error)
+
+	// GetModelQuality сравнивает прогноз дохода с подтвержденным за [from, to)
+	GetModelQuality(ctx context.Context, from, to time.Time) (*dto.ModelQualityResponse, error)
+}
+
+type PromoService interface {
+	ListCategories(ctx context.Context) ([]*dto.PromoCategoryResponse, error)
+
+	GetCategory(ctx context.Context, id int64) (*dto.PromoCategoryResponse, error)
+
+	CreateCategory(ctx context.Context, req *dto.PromoCategoryRequest) (*dto.PromoCategoryResponse, error)
+
+	UpdateCategory(ctx context.Context, id int64, req *dto.PromoCategoryRequest) (*dto.PromoCategoryResponse, error)
+
+	DeleteCategory(ctx context.Context, id int64) error
+
+	ListCampaigns(ctx context.Context) ([]*dto.PromoCampaignResponse, error)
+
+	GetCampaign(ctx context.Context, id int64) (*dto.PromoCampaignResponse, error)
+
+	CreateCampaign(ctx context.Context, req *dto.PromoCampaignRequest) (*dto.PromoCampaignResponse, error)
+
+	UpdateCampaign(ctx context.Context, id int64, req *dto.PromoCampaignRequest) (*dto.PromoCampaignResponse, error)
+
+	DeleteCampaign(ctx context.Context, id int64) error
+
+	// RecordOutcome сохраняет реакцию клиента (accepted / declined) на последний показ промо
+	RecordOutcome(ctx context.Context, clientID int64, promoID string, status string) (*dto.PromoImpressionResponse, error)
+
+	// GetStats возвращает конверсию промо за [from, to) по диапазонам прогноза дохода
+	GetStats(ctx context.Context, from, to time.Time) (*dto.PromoStatsResponse, error)
 }
 
 type ImportStats struct {
@@ -46,3 +98,21 @@ func (stats *ImportStats) AddError(lineNum int, err error) {
 type ImportService interface {
 	ImportClientsCSV(ctx context.Context, reader io.Reader) (*ImportStats, error)
 }
+
+type DriftService interface {
+	// BuildReference строит эталонные распределения по скорингам за [from, to)
+	BuildReference(ctx context.Context, from, to time.Time) (*dto.DriftReferenceResponse, error)
+
+	// GetDrift сравнивает текущее окно скорингов с эталоном
+	GetDrift(ctx context.Context) (*dto.DriftReport, error)
+}
+
+type HealthService interface {
+	// Readiness проверяет зависимости сервиса
+	Readiness(ctx context.Context) *dto.ReadinessResponse
+}
+
+type ModelComparisonService interface {
+	// GetComparison сравнивает варианты модели champion/challenger по скорингам за [from, to)
+	GetComparison(ctx context.Context, from, to time.Time) (*dto.ModelComparisonResponse, error)
+}
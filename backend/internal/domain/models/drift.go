@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// PredictionDriftName имя эталонного распределения прогнозов дохода
+const PredictionDriftName = "predict_income"
+
+// DriftReference эталонное распределение числового признака или прогноза:
+// границы бинов по квантилям, доли значений в бинах и доля пропусков
+type DriftReference struct {
+	ID          int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string         `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Edges       datatypes.JSON `json:"edges" gorm:"type:jsonb"`
+	Proportions datatypes.JSON `json:"proportions" gorm:"type:jsonb"`
+	MissingRate float64        `json:"missing_rate"`
+	SampleSize  int            `json:"sample_size"`
+	WindowFrom  time.Time      `json:"window_from"`
+	WindowTo    time.Time      `json:"window_to"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (DriftReference) TableName() string {
+	return "drift_references"
+}
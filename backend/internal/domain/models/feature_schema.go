@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+type FeatureType string
+
+const (
+	FeatureNumeric     FeatureType = "numeric"
+	FeatureCategorical FeatureType = "categorical"
+	FeatureFlag        FeatureType = "flag"
+)
+
+// FeatureDefinition описание одного признака ML-модели
+type FeatureDefinition struct {
+	Name         string      `json:"name"`
+	Type         FeatureType `json:"type"`
+	Min          *float64    `json:"min,omitempty"`
+	Max          *float64    `json:"max,omitempty"`
+	Enum         []string    `json:"enum,omitempty"`
+	Default      interface{} `json:"default"`
+	Important    bool        `json:"important,omitempty"`
+	Description  string      `json:"description"`
+	LabelRu      string      `json:"label_ru,omitempty"`
+	ModelVersion string      `json:"model_version"`
+}
+
+// FeatureSchema версионированный набор признаков, которые ожидает модель
+type FeatureSchema struct {
+	Version      string              `json:"version"`
+	ModelVersion string              `json:"model_version"`
+	Features     []FeatureDefinition `json:"features"`
+
+	index map[string]int
+}
+
+// FeatureViolation нарушение схемы одним значением признака
+type FeatureViolation struct {
+	Feature string
+	Rule    string
+	Message string
+}
+
+// Imputation итог заполнения пропусков: какие признаки схемы пришли
+// с данными клиента, а какие подставлены значениями по умолчанию
+type Imputation struct {
+	Supplied         []string
+	Imputed          []string
+	MissingImportant []string
+}
+
+// Completeness доля признаков схемы, переданных с данными клиента
+func (i *Imputation) Completeness() float64 {
+	total := len(i.Supplied) + len(i.Imputed)
+	if total == 0 {
+		return 1
+	}
+	return float64(len(i.Supplied)) / float64(total)
+}
+
+// Init проверяет схему и строит индекс по именам. Вызывается после загрузки.
+func (s *FeatureSchema) Init() error {
+	s.index = make(map[string]int, len(s.Features))
+	for i, def := range s.Features {
+		if def.Name == "" {
+			return fmt.Errorf("feature #%d has empty name", i)
+		}
+		if _, exists := s.index[def.Name]; exists {
+			return fmt.Errorf("feature %s is defined twice", def.Name)
+		}
+		switch def.Type {
+		case FeatureNumeric, FeatureCategorical, FeatureFlag:
+		default:
+			return fmt.Errorf("feature %s has unknown type %q", def.Name, def.Type)
+		}
+		if def.Default == nil {
+			return fmt.Errorf("feature %s has no default value", def.Name)
+		}
+		if v := def.Check(def.Default); v != nil {
+			return fmt.Errorf("feature %s has invalid default: %s", def.Name, v.Message)
+		}
+		s.index[def.Name] = i
+	}
+	return nil
+}
+
+func (s *FeatureSchema) Get(name string) (*FeatureDefinition, bool) {
+	idx, ok := s.index[name]
+	if !ok {
+		return nil, false
+	}
+	return &s.Features[idx], true
+}
+
+// Label русская подпись признака; для признаков вне схемы или без подписи -
+// описание или само имя
+func (s *FeatureSchema) Label(name string) string {
+	def, ok := s.Get(name)
+	if !ok {
+		return name
+	}
+	return def.Label()
+}
+
+// Names возвращает имена признаков в порядке схемы
+func (s *FeatureSchema) Names() []string {
+	names := make([]string, 0, len(s.Features))
+	for _, def := range s.Features {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// Validate проверяет значения известных признаков. Признаки вне схемы
+// пропускаются: их игнорирует модель, но они могут быть нужны для аналитики.
+func (s *FeatureSchema) Validate(features map[string]interface{}) []FeatureViolation {
+	var violations []FeatureViolation
+	for _, def := range s.Features {
+		value, ok := features[def.Name]
+		if !ok {
+			continue
+		}
+		if v := def.Check(value); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// Impute возвращает копию features, где отсутствующие признаки схемы
+// заполнены значениями по умолчанию, и отчет о том, что было подставлено
+func (s *FeatureSchema) Impute(features map[string]interface{}) (map[string]interface{}, *Imputation) {
+	result := make(map[string]interface{}, len(s.Features)+len(features))
+	for k, v := range features {
+		result[k] = v
+	}
+
+	imputation := &Imputation{
+		Supplied:         []string{},
+		Imputed:          []string{},
+		MissingImportant: []string{},
+	}
+	for _, def := range s.Features {
+		if value, exists := result[def.Name]; exists && value != nil {
+			imputation.Supplied = append(imputation.Supplied, def.Name)
+			continue
+		}
+		result[def.Name] = def.Default
+		imputation.Imputed = append(imputation.Imputed, def.Name)
+		if def.Important {
+			imputation.MissingImportant = append(imputation.MissingImportant, def.Name)
+		}
+	}
+
+	return result, imputation
+}
+
+// Check проверяет одно значение признака на соответствие типу, диапазону и списку допустимых значений
+func (d *FeatureDefinition) Check(value interface{}) *FeatureViolation {
+	switch d.Type {
+	case FeatureNumeric:
+		number, ok := numericValue(value)
+		if !ok {
+			return d.violation("type", "must be a number")
+		}
+		if d.Min != nil && number < *d.Min {
+			return d.violation("min", fmt.Sprintf("must be >= %v", *d.Min))
+		}
+		if d.Max != nil && number > *d.Max {
+			return d.violation("max", fmt.Sprintf("must be <= %v", *d.Max))
+		}
+	case FeatureFlag:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+		number, ok := numericValue(value)
+		if !ok || (number != 0 && number != 1) {
+			return d.violation("flag", "must be 0 or 1")
+		}
+	case FeatureCategorical:
+		var category string
+		switch v := value.(type) {
+		case string:
+			category = v
+		case float64:
+			category = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			category = strconv.Itoa(v)
+		case int64:
+			category = strconv.FormatInt(v, 10)
+		default:
+			return d.violation("type", "must be a string or a number")
+		}
+		if len(d.Enum) > 0 && !slices.Contains(d.Enum, category) {
+			return d.violation("enum", fmt.Sprintf("must be one of %v", d.Enum))
+		}
+	}
+	return nil
+}
+
+// Label русская подпись признака
+func (d *FeatureDefinition) Label() string {
+	if d.LabelRu != "" {
+		return d.LabelRu
+	}
+	if d.Description != "" {
+		return d.Description
+	}
+	return d.Name
+}
+
+func (d *FeatureDefinition) violation(rule, message string) *FeatureViolation {
+	return &FeatureViolation{
+		Feature: d.Name,
+		Rule:    rule,
+		Message: fmt.Sprintf("feature %s %s", d.Name, message),
+	}
+}
+
+func numericValue(value interface{}) (float64, bool) {
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case float32:
+		number = float64(v)
+	case int:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	default:
+		return 0, false
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
@@ -1,13 +1,256 @@
 package models
 
-type PromoAction struct {
-	MinIncome int64  `json:"min_income"`
-	MaxIncome int64  `json:"max_income"`
-	Promo     string `json:"promo"`
-}
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Виды рекомендаций, к которым относится категория промо
+const (
+	PromoKindPromo      = "promo"
+	PromoKindCard       = "card"
+	PromoKindInvestment = "investment"
+)
 
+// PromoCategory категория промо-предложений (акции, карты, инвестиции).
+// Клиенту показывается не больше одного предложения из категории.
 type PromoCategory struct {
-	PromoActions []PromoAction `json:"promo_actions"`
-	Cards        []PromoAction `json:"cards"`
-	Investment   []PromoAction `json:"investment"`
+	ID        int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code      string          `json:"code" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Name      string          `json:"name" gorm:"type:varchar(100);not null"`
+	Kind      string          `json:"kind" gorm:"type:varchar(20);not null;default:promo"`
+	SortOrder int             `json:"sort_order" gorm:"not null;default:0"`
+	Bands     []PromoBand     `json:"bands" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	Campaigns []PromoCampaign `json:"-" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (PromoCategory) TableName() string {
+	return "promo_categories"
+}
+
+// PromoBand предложение для диапазона дохода [MinIncome, MaxIncome)
+type PromoBand struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CategoryID  int64     `json:"category_id" gorm:"not null;index"`
+	MinIncome   int64     `json:"min_income" gorm:"not null"`
+	MaxIncome   int64     `json:"max_income" gorm:"not null"`
+	Promo       string    `json:"promo" gorm:"type:text;not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	CTAURL      string    `json:"cta_url" gorm:"column:cta_url;type:varchar(500)"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (PromoBand) TableName() string {
+	return "promo_bands"
+}
+
+// Matches проверяет, попадает ли доход в диапазон
+func (b *PromoBand) Matches(income int64) bool {
+	return income >= b.MinIncome && income < b.MaxIncome
+}
+
+// PromoCampaign ограниченная по времени кампания внутри категории промо.
+// Подходящая кампания с наибольшим приоритетом вытесняет предложение по диапазону дохода.
+type PromoCampaign struct {
+	ID          int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	CategoryID  int64          `json:"category_id" gorm:"not null;index"`
+	Name        string         `json:"name" gorm:"type:varchar(200);not null"`
+	Promo       string         `json:"promo" gorm:"type:text;not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	CTAURL      string         `json:"cta_url" gorm:"column:cta_url;type:varchar(500)"`
+	StartsAt    time.Time      `json:"starts_at" gorm:"not null;index"`
+	EndsAt      *time.Time     `json:"ends_at" gorm:"index"`
+	Priority    int            `json:"priority" gorm:"not null;default:0"`
+	MaxIssued   int64          `json:"max_issued" gorm:"not null;default:0"`
+	IssuedCount int64          `json:"issued_count" gorm:"not null;default:0"`
+	Enabled     bool           `json:"enabled" gorm:"not null;default:true"`
+	Targeting   datatypes.JSON `json:"targeting" gorm:"type:jsonb"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (PromoCampaign) TableName() string {
+	return "promo_campaigns"
+}
+
+// PromoCampaignIssue выдача кампании клиенту. Один клиент занимает не больше
+// одного места в лимите кампании, сколько бы раз его ни скорили.
+type PromoCampaignIssue struct {
+	CampaignID int64     `json:"campaign_id" gorm:"primaryKey;autoIncrement:false"`
+	ClientID   int64     `json:"client_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Campaign *PromoCampaign `json:"-" gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (PromoCampaignIssue) TableName() string {
+	return "promo_campaign_issues"
+}
+
+// IsActive проверяет, что кампания включена и now попадает в [StartsAt, EndsAt)
+func (c *PromoCampaign) IsActive(now time.Time) bool {
+	if !c.Enabled || now.Before(c.StartsAt) {
+		return false
+	}
+	return c.EndsAt == nil || now.Before(*c.EndsAt)
+}
+
+// HasCapacity проверяет лимит выдач. MaxIssued = 0 - без ограничения.
+func (c *PromoCampaign) HasCapacity() bool {
+	return c.MaxIssued == 0 || c.IssuedCount < c.MaxIssued
+}
+
+// Rules разбирает условия таргетинга
+func (c *PromoCampaign) Rules() ([]TargetingRule, error) {
+	if len(c.Targeting) == 0 {
+		return nil, nil
+	}
+	var rules []TargetingRule
+	if err := json.Unmarshal(c.Targeting, &rules); err != nil {
+		return nil, fmt.Errorf("invalid targeting of campaign %d: %w", c.ID, err)
+	}
+	return rules, nil
+}
+
+// Операторы условий таргетинга
+const (
+	TargetEq    = "eq"
+	TargetNe    = "ne"
+	TargetIn    = "in"
+	TargetNotIn = "not_in"
+	TargetGt    = "gt"
+	TargetGte   = "gte"
+	TargetLt    = "lt"
+	TargetLte   = "lte"
+)
+
+// TargetingRule условие на признак клиента. Имя с * на конце (vert_has_app_*)
+// выполняется, если условию удовлетворяет хотя бы один признак с таким префиксом.
+type TargetingRule struct {
+	Feature  string      `json:"feature"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// Validate проверяет оператор и тип значения
+func (r *TargetingRule) Validate() error {
+	if r.Feature == "" || r.Feature == "*" {
+		return fmt.Errorf("feature is required")
+	}
+	switch r.Operator {
+	case TargetEq, TargetNe:
+		if _, ok := r.Value.([]interface{}); ok || r.Value == nil {
+			return fmt.Errorf("operator %s expects a single value", r.Operator)
+		}
+	case TargetIn, TargetNotIn:
+		if _, ok := r.Value.([]interface{}); !ok {
+			return fmt.Errorf("operator %s expects a list of values", r.Operator)
+		}
+	case TargetGt, TargetGte, TargetLt, TargetLte:
+		if _, ok := numericValue(r.Value); !ok {
+			return fmt.Errorf("operator %s expects a number", r.Operator)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q", r.Operator)
+	}
+	return nil
+}
+
+var targetOperatorSymbols = map[string]string{
+	TargetEq:    "=",
+	TargetNe:    "!=",
+	TargetIn:    "in",
+	TargetNotIn: "not in",
+	TargetGt:    ">",
+	TargetGte:   ">=",
+	TargetLt:    "<",
+	TargetLte:   "<=",
+}
+
+// String описание условия для объяснения, почему клиенту показано предложение
+func (r TargetingRule) String() string {
+	symbol, ok := targetOperatorSymbols[r.Operator]
+	if !ok {
+		symbol = r.Operator
+	}
+	return fmt.Sprintf("%s %s %v", r.Feature, symbol, r.Value)
+}
+
+// Matches проверяет условие на наборе значений признаков
+func (r *TargetingRule) Matches(values map[string]interface{}) bool {
+	if prefix, ok := strings.CutSuffix(r.Feature, "*"); ok {
+		for name, value := range values {
+			if strings.HasPrefix(name, prefix) && r.matchValue(value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	value, ok := values[r.Feature]
+	if !ok {
+		return false
+	}
+	return r.matchValue(value)
+}
+
+func (r *TargetingRule) matchValue(value interface{}) bool {
+	switch r.Operator {
+	case TargetEq:
+		return sameValue(value, r.Value)
+	case TargetNe:
+		return !sameValue(value, r.Value)
+	case TargetIn, TargetNotIn:
+		list, _ := r.Value.([]interface{})
+		found := slices.ContainsFunc(list, func(item interface{}) bool { return sameValue(value, item) })
+		return found == (r.Operator == TargetIn)
+	}
+
+	number, ok := numericValue(value)
+	threshold, okThreshold := numericValue(r.Value)
+	if !ok || !okThreshold {
+		return false
+	}
+	switch r.Operator {
+	case TargetGt:
+		return number > threshold
+	case TargetGte:
+		return number >= threshold
+	case TargetLt:
+		return number < threshold
+	case TargetLte:
+		return number <= threshold
+	}
+	return false
+}
+
+// sameValue сравнивает числа численно (флаг true равен 1), остальное - как строки
+func sameValue(a, b interface{}) bool {
+	if x, ok := b.(bool); ok {
+		b = boolNumber(x)
+	}
+	if x, ok := a.(bool); ok {
+		a = boolNumber(x)
+	}
+	na, okA := numericValue(a)
+	nb, okB := numericValue(b)
+	if okA && okB {
+		return na == nb
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func boolNumber(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
 }
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Статусы показа промо
+const (
+	ImpressionShown    = "shown"
+	ImpressionAccepted = "accepted"
+	ImpressionDeclined = "declined"
+)
+
+// PromoImpression показ рекомендации клиенту в скоринге и реакция клиента на нее
+type PromoImpression struct {
+	ID        int64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClientID  int64 `json:"client_id" gorm:"not null;index:idx_promo_impressions_client_promo,priority:1"`
+	ScoringID int64 `json:"scoring_id" gorm:"not null;index"`
+
+	PromoID       string  `json:"promo_id" gorm:"type:varchar(50);not null;index:idx_promo_impressions_client_promo,priority:2"`
+	Category      string  `json:"category" gorm:"type:varchar(20)"`
+	Title         string  `json:"title" gorm:"type:text"`
+	PredictIncome float64 `json:"predict_income"`
+
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:shown"`
+	RespondedAt *time.Time `json:"responded_at"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+func (PromoImpression) TableName() string {
+	return "promo_impressions"
+}
+
+// PromoImpressionStat агрегат показов одного промо в одном диапазоне дохода.
+// Band - номер диапазона по границам статистики (0 - ниже первой границы).
+type PromoImpressionStat struct {
+	PromoID     string
+	Category    string
+	Title       string
+	Band        int
+	Impressions int64
+	Accepted    int64
+	Declined    int64
+}
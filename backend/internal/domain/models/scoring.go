@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
 type ScoringResult struct {
 	PredictIncome   float64            `json:"predict_income"`
 	Recommendations []string           `json:"recommendations"`
@@ -13,3 +19,47 @@ type ScoringResult struct {
 func (s *ScoringResult) IsValid() bool {
 	return s.PredictIncome >= 0
 }
+
+// ScoringRecord сохраненный результат одного запуска скоринга
+type ScoringRecord struct {
+	ID       int64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClientID int64 `json:"client_id" gorm:"not null;index:idx_scoring_results_client_created,priority:1"`
+
+	PredictIncome  float64 `json:"predict_income"`
+	CreditLimit    float64 `json:"credit_limit"`
+	MaxCreditLimit float64 `json:"max_credit_limit"`
+
+	Recommendations datatypes.JSON `json:"recommendations" gorm:"type:jsonb"`
+	PositiveFactors datatypes.JSON `json:"positive_factors" gorm:"type:jsonb"`
+	NegativeFactors datatypes.JSON `json:"negative_factors" gorm:"type:jsonb"`
+	// FactorCategories суммарный вклад по категориям признаков
+	FactorCategories datatypes.JSON `json:"factor_categories" gorm:"type:jsonb"`
+
+	ModelVersion    string `json:"model_version" gorm:"type:varchar(50)"`
+	PipelineVersion string `json:"pipeline_version" gorm:"type:varchar(50)"`
+	MLUID           string `json:"ml_uid" gorm:"column:ml_uid;type:varchar(100)"`
+	// ModelVariant вариант модели champion/challenger, которым посчитан прогноз
+	ModelVariant string `json:"model_variant" gorm:"type:varchar(50)"`
+
+	DataCompleteness         float64        `json:"data_completeness"`
+	InsufficientData         bool           `json:"insufficient_data" gorm:"not null;default:false"`
+	ImputedFeatures          datatypes.JSON `json:"imputed_features" gorm:"type:jsonb"`
+	MissingImportantFeatures datatypes.JSON `json:"missing_important_features" gorm:"type:jsonb"`
+
+	Decision       string         `json:"decision" gorm:"type:varchar(20)"`
+	DeclineReasons datatypes.JSON `json:"decline_reasons" gorm:"type:jsonb"`
+
+	PolicyVersion   string         `json:"policy_version" gorm:"type:varchar(50)"`
+	CreditBreakdown datatypes.JSON `json:"credit_breakdown" gorm:"type:jsonb"`
+
+	// Features признаки после импутации, с которыми считался скоринг
+	Features datatypes.JSON `json:"features" gorm:"type:jsonb"`
+	// ScoredAt время расчета прогноза в ML; у прогноза из кэша раньше CreatedAt
+	ScoredAt *time.Time `json:"scored_at"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index:idx_scoring_results_client_created,priority:2"`
+}
+
+func (ScoringRecord) TableName() string {
+	return "scoring_results"
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// DefaultModelVariant вариант модели, когда варианты champion/challenger не настроены
+const DefaultModelVariant = "default"
+
+// ShadowPrediction прогноз теневого варианта модели для сохраненного скоринга.
+// Клиенту не возвращается, нужен только для сравнения с боевым вариантом.
+type ShadowPrediction struct {
+	ID        int64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	ScoringID int64 `json:"scoring_id" gorm:"not null;index"`
+	ClientID  int64 `json:"client_id" gorm:"not null"`
+
+	// ChampionVariant боевой вариант, которым посчитан сам скоринг
+	ChampionVariant string `json:"champion_variant" gorm:"type:varchar(50)"`
+	Variant         string `json:"variant" gorm:"type:varchar(50);not null"`
+	ModelVersion    string `json:"model_version" gorm:"type:varchar(50)"`
+	PipelineVersion string `json:"pipeline_version" gorm:"type:varchar(50)"`
+
+	PredictIncome float64 `json:"predict_income"`
+	CreditLimit   float64 `json:"credit_limit"`
+	LatencyMs     int64   `json:"latency_ms"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+func (ShadowPrediction) TableName() string {
+	return "shadow_predictions"
+}
+
+// ShadowComparisonStat агрегат расхождений теневого варианта с боевым
+type ShadowComparisonStat struct {
+	ChampionVariant   string
+	ChallengerVariant string
+	Pairs             int64
+	AgreementRate     float64
+	MeanIncomeDiff    float64
+	MeanAbsIncomeDiff float64
+	MeanLimitDiff     float64
+	MeanAbsLimitDiff  float64
+	HigherLimitRate   float64
+	LowerLimitRate    float64
+}
+
+// ModelVariantStat трафик боевого варианта модели
+type ModelVariantStat struct {
+	Variant          string
+	Scorings         int64
+	AvgPredictIncome float64
+	AvgCreditLimit   float64
+}
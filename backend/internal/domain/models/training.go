@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Источники подтверждения дохода
+const (
+	IncomeSourcePayslip       = "payslip"
+	IncomeSourceBankStatement = "bank_statement"
+	IncomeSourceTaxReturn     = "tax_return"
+	IncomeSourceSalaryProject = "salary_project"
+	IncomeSourceOther         = "other"
+)
+
+// IncomeConfirmation подтвержденный фактический доход клиента
+type IncomeConfirmation struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClientID    int64     `json:"client_id" gorm:"not null;index"`
+	Income      float64   `json:"income" gorm:"not null"`
+	Source      string    `json:"source" gorm:"type:varchar(30);not null"`
+	ConfirmedAt time.Time `json:"confirmed_at" gorm:"type:date;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (IncomeConfirmation) TableName() string {
+	return "income_confirmations"
+}
+
+// Статусы отправки размеченных примеров в ML-сервис
+const (
+	TrainingPending = "pending"
+	TrainingSending = "sending"
+	TrainingSent    = "sent"
+	TrainingFailed  = "failed"
+)
+
+// TrainingSample размеченный пример для дообучения: признаки скоринга
+// и подтвержденный доход клиента в качестве целевой переменной
+type TrainingSample struct {
+	ID             int64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClientID       int64 `json:"client_id" gorm:"not null;index"`
+	ConfirmationID int64 `json:"confirmation_id" gorm:"not null;uniqueIndex"`
+	ScoringID      int64 `json:"scoring_id" gorm:"not null"`
+
+	Features        datatypes.JSON `json:"features" gorm:"type:jsonb;not null"`
+	Target          float64        `json:"target" gorm:"not null"`
+	PredictIncome   float64        `json:"predict_income"`
+	ModelVersion    string         `json:"model_version" gorm:"type:varchar(50)"`
+	PipelineVersion string         `json:"pipeline_version" gorm:"type:varchar(50)"`
+
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:pending;index:idx_training_samples_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_training_samples_due,priority:2"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	BatchID       string     `json:"batch_id" gorm:"type:varchar(36)"`
+	SentAt        *time.Time `json:"sent_at"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (TrainingSample) TableName() string {
+	return "training_samples"
+}
+
+// TrainingDeliveryStat число примеров в одном статусе отправки
+type TrainingDeliveryStat struct {
+	Status string
+	Count  int64
+}
+
+// LabeledOutcome прогноз и подтвержденный доход одного примера для оценки качества модели
+type LabeledOutcome struct {
+	Target        float64
+	PredictIncome float64
+	ModelVersion  string
+	Region        string
+	BirthDate     *time.Time
+	ConfirmedAt   time.Time
+}
@@ -1,11 +1,16 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/application/services"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/infrastructure/features"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/infrastructure/http"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/infrastructure/http/handlers"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/infrastructure/promo"
@@ -22,18 +27,47 @@ type Container struct {
 	RepositoryProvider providers.RepositoryProvider
 	MLServiceProvider  providers.MLServiceProvider
 
-	DB       *gorm.DB
-	MLClient interfaces.MLService
-
-	ClientRepo interfaces.ClientRepository
-
-	ClientService  interfaces.ClientService
-	ScoringService interfaces.ScoringService
-	ImportService  interfaces.ImportService
-
-	ClientHandler *handlers.ClientHandler
+	DB            *gorm.DB
+	MLClient      interfaces.MLService
+	ModelRouter   *services.ModelRouter
+	ShadowPool    *services.ShadowPool
+	Predictions   *services.PredictionCache
+	FeatureSchema *models.FeatureSchema
+
+	ClientRepo     interfaces.ClientRepository
+	ScoringRepo    interfaces.ScoringRepository
+	PromoRepo      interfaces.PromoRepository
+	ImpressionRepo interfaces.PromoImpressionRepository
+	TrainingRepo   interfaces.TrainingRepository
+	DriftRepo      interfaces.DriftRepository
+	ShadowRepo     interfaces.ShadowPredictionRepository
+
+	ClientService   interfaces.ClientService
+	ScoringService  interfaces.ScoringService
+	ImportService   interfaces.ImportService
+	PromoService    interfaces.PromoService
+	OfferService    interfaces.OfferService
+	TrainingService interfaces.TrainingService
+	DriftService    interfaces.DriftService
+	HealthService   interfaces.HealthService
+	ModelComparison interfaces.ModelComparisonService
+
+	TrainingExporter *services.TrainingExporter
+	DriftMonitor     *services.DriftMonitor
+
+	ClientHandler   *handlers.ClientHandler
+	FeatureHandler  *handlers.FeatureHandler
+	OfferHandler    *handlers.OfferHandler
+	PromoHandler    *handlers.PromoHandler
+	TrainingHandler *handlers.TrainingHandler
+	DriftHandler    *handlers.DriftHandler
+	HealthHandler   *handlers.HealthHandler
+	ModelHandler    *handlers.ModelHandler
 
 	HTTPServer *http.Server
+
+	stopJobs context.CancelFunc
+	jobs     sync.WaitGroup
 }
 
 type Options struct {
@@ -62,10 +96,18 @@ func New(cfg *config.Config, opts *Options) (*Container, error) {
 		return nil, fmt.Errorf("failed to initialize repositories: %w", err)
 	}
 
+	if err := c.initPromoCatalog(); err != nil {
+		return nil, fmt.Errorf("failed to initialize promo catalog: %w", err)
+	}
+
 	if err := c.initMLClient(); err != nil {
 		return nil, fmt.Errorf("failed to initialize ML client: %w", err)
 	}
 
+	if err := c.initFeatureSchema(); err != nil {
+		return nil, fmt.Errorf("failed to load feature schema: %w", err)
+	}
+
 	if err := c.initServices(); err != nil {
 		return nil, fmt.Errorf("failed to initialize services: %w", err)
 	}
@@ -137,32 +179,176 @@ func (c *Container) initDatabase() error {
 
 func (c *Container) initRepositories() error {
 	c.ClientRepo = c.RepositoryProvider.ProvideClientRepository(c.DB, c.Logger)
+	c.ScoringRepo = c.RepositoryProvider.ProvideScoringRepository(c.DB, c.Logger)
+	c.PromoRepo = c.RepositoryProvider.ProvidePromoRepository(c.DB, c.Logger)
+	c.ImpressionRepo = c.RepositoryProvider.ProvidePromoImpressionRepository(c.DB, c.Logger)
+	c.TrainingRepo = c.RepositoryProvider.ProvideTrainingRepository(c.DB, c.Logger)
+	c.DriftRepo = c.RepositoryProvider.ProvideDriftRepository(c.DB, c.Logger)
+	c.ShadowRepo = c.RepositoryProvider.ProvideShadowPredictionRepository(c.DB, c.Logger)
 	return nil
 }
 
 func (c *Container) initMLClient() error {
-	c.MLClient = c.MLServiceProvider.ProvideMLService(c.Config, c.Logger)
+	mlClient, err := c.MLServiceProvider.ProvideMLService(c.Config, c.Logger)
+	if err != nil {
+		return err
+	}
+
+	c.MLClient = mlClient
+
+	variantServices, err := c.MLServiceProvider.ProvideModelVariants(c.Config, c.Logger)
+	if err != nil {
+		return err
+	}
+
+	variants := make([]services.ModelVariant, 0, len(c.Config.ML.Routing.Variants))
+	for _, variant := range c.Config.ML.Routing.Variants {
+		pipelineVersion := variant.PipelineVersion
+		if pipelineVersion == "" {
+			pipelineVersion = c.Config.ML.PipelineVersion
+		}
+		variants = append(variants, services.ModelVariant{
+			Name:            variant.Name,
+			ModelVersion:    variant.ModelVersion,
+			PipelineVersion: pipelineVersion,
+			Weight:          variant.Weight,
+			Shadow:          variant.Shadow,
+			Service:         variantServices[variant.Name],
+		})
+	}
+	c.ModelRouter = services.NewModelRouter(c.MLClient, variants, c.Config.ML)
+	c.ShadowPool = services.NewShadowPool(c.Config.ML.Routing, c.Logger)
+	return nil
+}
+
+// initPromoCatalog заполняет пустой каталог промо начальными тирами
+func (c *Container) initPromoCatalog() error {
+	_, err := c.PromoRepo.SeedIfEmpty(context.Background(), promo.DefaultCatalog())
+	return err
+}
+
+func (c *Container) initFeatureSchema() error {
+	schema, err := features.LoadSchema(c.Config.Features.SchemaPath)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Info("Feature schema loaded",
+		"version", schema.Version,
+		"model_version", schema.ModelVersion,
+		"features", len(schema.Features),
+	)
+	c.FeatureSchema = schema
 	return nil
 }
 
 func (c *Container) initServices() error {
-	promoProvider := promo.NewStaticPromoProvider()
+	promoProvider := promo.NewDBPromoProvider(
+		c.PromoRepo,
+		time.Duration(c.Config.Promo.CacheTTL)*time.Second,
+		c.Logger,
+	)
+
+	c.Predictions = services.NewPredictionCache(c.Config.Scoring.Cache)
 
 	c.ClientService = services.NewClientService(
 		c.ClientRepo,
 		c.MLClient,
+		c.Predictions,
+		c.FeatureSchema,
 		c.Logger,
 	)
 
 	c.ScoringService = services.NewScoringService(
 		c.ClientRepo,
-		c.MLClient,
+		c.ScoringRepo,
+		c.ModelRouter,
+		c.ShadowRepo,
+		c.ShadowPool,
+		c.Predictions,
 		promoProvider,
+		c.ImpressionRepo,
+		c.FeatureSchema,
+		c.Config.Scoring,
 		c.Logger,
 	)
 
 	c.ImportService = services.NewImportService(
 		c.ClientRepo,
+		c.FeatureSchema,
+		c.Logger,
+	)
+
+	c.PromoService = services.NewPromoService(
+		c.PromoRepo,
+		c.ImpressionRepo,
+		c.FeatureSchema,
+		c.Config.Promo,
+		c.Logger,
+	)
+
+	c.OfferService = services.NewOfferService(
+		c.ScoringService,
+		c.Config.Offers,
+		c.Config.Scoring.CreditPolicy,
+		c.Logger,
+	)
+
+	c.TrainingService = services.NewTrainingService(
+		c.ClientRepo,
+		c.ScoringRepo,
+		c.TrainingRepo,
+		c.Config.Quality,
+		c.Logger,
+	)
+
+	c.TrainingExporter = services.NewTrainingExporter(
+		c.TrainingRepo,
+		c.MLClient,
+		c.Config.Training,
+		c.Logger,
+	)
+
+	c.DriftService = services.NewDriftService(
+		c.ScoringRepo,
+		c.DriftRepo,
+		c.FeatureSchema,
+		c.Config.Drift,
+		c.Logger,
+	)
+
+	c.DriftMonitor = services.NewDriftMonitor(c.DriftService, c.Config.Drift, c.Logger)
+
+	c.ModelComparison = services.NewModelComparisonService(
+		c.ScoringRepo,
+		c.ShadowRepo,
+		c.ModelRouter,
+		c.Logger,
+	)
+
+	sqlDB, err := c.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get SQL DB: %w", err)
+	}
+	dependencies := []services.HealthDependency{
+		{Name: "postgres", Required: true, Check: sqlDB.PingContext},
+		{Name: "ml_service", Required: c.Config.Health.MLRequired, Check: c.MLClient.HealthCheck},
+	}
+	// варианты из ml.routing: боевые обязательны наравне с ML-сервисом, теневые - нет
+	for _, variant := range c.ModelRouter.Variants() {
+		if variant.Name == models.DefaultModelVariant {
+			continue
+		}
+		dependencies = append(dependencies, services.HealthDependency{
+			Name:     "ml_variant:" + variant.Name,
+			Required: c.Config.Health.MLRequired && !variant.Shadow,
+			Check:    variant.Service.HealthCheck,
+		})
+	}
+	c.HealthService = services.NewHealthService(
+		dependencies,
+		time.Duration(c.Config.Health.Timeout)*time.Second,
+		config.Version,
 		c.Logger,
 	)
 
@@ -176,6 +362,13 @@ func (c *Container) initHandlers() error {
 		c.ImportService,
 		c.Logger,
 	)
+	c.FeatureHandler = handlers.NewFeatureHandler(c.FeatureSchema, c.Logger)
+	c.OfferHandler = handlers.NewOfferHandler(c.OfferService, c.Logger)
+	c.PromoHandler = handlers.NewPromoHandler(c.PromoService, c.Logger)
+	c.TrainingHandler = handlers.NewTrainingHandler(c.TrainingService, c.Logger)
+	c.DriftHandler = handlers.NewDriftHandler(c.DriftService, c.Logger)
+	c.HealthHandler = handlers.NewHealthHandler(c.HealthService, config.Version, c.Logger)
+	c.ModelHandler = handlers.NewModelHandler(c.ModelComparison, c.Logger)
 	return nil
 }
 
@@ -183,14 +376,59 @@ func (c *Container) initHTTPServer() error {
 	c.HTTPServer = http.NewServer(
 		c.Config,
 		c.ClientHandler,
+		c.FeatureHandler,
+		c.OfferHandler,
+		c.PromoHandler,
+		c.TrainingHandler,
+		c.DriftHandler,
+		c.HealthHandler,
+		c.ModelHandler,
 		c.Logger,
 	)
 	return nil
 }
 
+// StartJobs запускает фоновые задачи: выгрузку примеров для дообучения
+// и проверку дрейфа.
+// Задачи останавливаются в Close.
+func (c *Container) StartJobs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopJobs = cancel
+
+	switch {
+	case c.Config.Training.Enabled && c.Config.ML.Backend == "local":
+		// встроенной модели некуда отправлять примеры, они копятся до смены backend
+		c.Logger.Warn("Training export skipped: local ML backend does not accept training data")
+	case c.Config.Training.Enabled:
+		c.jobs.Add(1)
+		go func() {
+			defer c.jobs.Done()
+			c.TrainingExporter.Run(ctx)
+		}()
+	}
+
+	if c.Config.Drift.CheckInterval > 0 {
+		c.jobs.Add(1)
+		go func() {
+			defer c.jobs.Done()
+			c.DriftMonitor.Run(ctx)
+		}()
+	}
+}
+
 func (c *Container) Close() error {
 	c.Logger.Info("Closing container resources")
 
+	if c.stopJobs != nil {
+		c.stopJobs()
+		c.jobs.Wait()
+	}
+
+	// теневые вызовы пишут в БД, поэтому останавливаются до ее закрытия
+	if c.ShadowPool != nil {
+		c.ShadowPool.Close()
+	}
+
 	if c.DB != nil {
 		sqlDB, err := c.DB.DB()
 		if err != nil {
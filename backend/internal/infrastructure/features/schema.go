@@ -0,0 +1,38 @@
+package features
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// defaultSchema схема признаков, поставляемая вместе с сервисом
+//
+//go:embed schema.v1.json
+var defaultSchema []byte
+
+// LoadSchema загружает схему признаков из файла. Пустой путь — встроенная схема.
+func LoadSchema(path string) (*models.FeatureSchema, error) {
+	data := defaultSchema
+	if path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read feature schema: %w", err)
+		}
+		data = fileData
+	}
+
+	var schema models.FeatureSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode feature schema: %w", err)
+	}
+
+	if err := schema.Init(); err != nil {
+		return nil, fmt.Errorf("invalid feature schema: %w", err)
+	}
+
+	return &schema, nil
+}
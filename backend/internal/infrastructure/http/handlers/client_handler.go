@@ -13,18 +13,18 @@ import (
 )
 
 type ClientHandler struct {
+	baseHandler
 	clientService  interfaces.ClientService
 	scoringService interfaces.ScoringService
 	importService  interfaces.ImportService
-	logger         interfaces.Logger
 }
 
 func NewClientHandler(clientService interfaces.ClientService, scoringService interfaces.ScoringService, importService interfaces.ImportService, logger interfaces.Logger) *ClientHandler {
 	return &ClientHandler{
+		baseHandler:    baseHandler{logger: logger.With("component", "ClientHandler")},
 		clientService:  clientService,
 		scoringService: scoringService,
 		importService:  importService,
-		logger:         logger.With("component", "ClientHandler"),
 	}
 }
 
@@ -111,14 +111,17 @@ func (h *ClientHandler) SearchClients(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Расчет ML-скоринга
-// @Description  Запускает ML-модель для расчета скора клиента и получения рекомендаций
+// @Description  Запускает ML-модель для расчета скора клиента и получения рекомендаций. Если ML-сервис недоступен, а признаки клиента не менялись с последнего скоринга, возвращается его прогноз с stale: true и возрастом stale_age_seconds; лимит и промо считаются заново (scoring.stale_fallback).
 // @Tags         scoring
 // @Produce      json
 // @Param        id   path      int  true  "Client ID"
+// @Param        breakdown  query     bool  false  "Включить детализацию расчета лимита"
 // @Success      200  {object}  dto.ScoringResponse
 // @Failure      400  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ErrorResponse  "У клиента нет признаков или недостаточно данных (scoring.reject_insufficient_data)"
 // @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse  "ML-сервис недоступен, см. заголовок Retry-After"
 // @Router       /api/clients/{id}/scoring [get]
 func (h *ClientHandler) CalculateScoring(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -135,11 +138,258 @@ func (h *ClientHandler) CalculateScoring(w http.ResponseWriter, r *http.Request)
 			h.respondError(w, http.StatusNotFound, "client not found")
 			return
 		}
+		if errors.Is(err, domainerrors.ErrMLServiceUnavailable) {
+			h.logger.Warn("ML service unavailable", "id", id, "error", err)
+			h.respondMLUnavailable(w, err)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrNoFeatures) || errors.Is(err, domainerrors.ErrInsufficientData) {
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "insufficient data for scoring", Message: err.Error()})
+			return
+		}
 		h.logger.Error("Failed to calculate scoring", "id", id, "error", err)
 		h.respondError(w, http.StatusInternalServerError, "failed to calculate scoring")
 		return
 	}
 
+	stripBreakdown(r, result)
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// CalculateBatchScoring запускает скоринг для набора клиентов
+// @Summary      Пакетный скоринг
+// @Description  Рассчитывает скоринг для списка ID клиентов или для клиентов, найденных по фильтру. Ошибки по отдельным клиентам (not_found, no_features, ml_failure) возвращаются в errors и не прерывают пакет.
+// @Tags         scoring
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.BatchScoringRequest true "ID клиентов или фильтр поиска"
+// @Param        breakdown  query     bool  false  "Включить детализацию расчета лимита"
+// @Success      200  {object}  dto.BatchScoringResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/scoring/batch [post]
+func (h *ClientHandler) CalculateBatchScoring(w http.ResponseWriter, r *http.Request) {
+	var req dto.BatchScoringRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.scoringService.CalculateBatchScoring(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidInput) {
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid batch request", Message: err.Error()})
+			return
+		}
+		h.logger.Error("Failed to calculate batch scoring", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to calculate batch scoring")
+		return
+	}
+
+	stripBreakdown(r, result.Results...)
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// ScoreApplicant считает скоринг заявителя без сохранения в базу
+// @Summary      Скоринг заявителя
+// @Description  Рассчитывает скоринг по данным и признакам, переданным в запросе, для человека, который еще не заведен как клиент. Ничего не сохраняет, поле id в ответе равно 0.
+// @Tags         scoring
+// @Accept       json
+// @Produce      json
+// @Param        input  body  dto.ApplicantScoringRequest  true  "Данные заявителя и признаки"
+// @Param        breakdown  query     bool  false  "Включить детализацию расчета лимита"
+// @Success      200  {object}  dto.ScoringResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse  "ML-сервис недоступен, см. заголовок Retry-After"
+// @Router       /api/scoring [post]
+func (h *ClientHandler) ScoreApplicant(w http.ResponseWriter, r *http.Request) {
+	var req dto.ApplicantScoringRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateApplicantScoringRequest(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	result, err := h.scoringService.ScoreApplicant(r.Context(), &req)
+	if err != nil {
+		var validationErr *domainerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.respondDomainValidation(w, validationErr)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrInvalidInput) || errors.Is(err, domainerrors.ErrInvalidFormat) {
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid applicant data", Message: err.Error()})
+			return
+		}
+		if errors.Is(err, domainerrors.ErrMLServiceUnavailable) {
+			h.logger.Warn("ML service unavailable", "error", err)
+			h.respondMLUnavailable(w, err)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrNoFeatures) || errors.Is(err, domainerrors.ErrInsufficientData) {
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "insufficient data for scoring", Message: err.Error()})
+			return
+		}
+		h.logger.Error("Failed to score applicant", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to score applicant")
+		return
+	}
+
+	stripBreakdown(r, result)
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// SimulateScoring считает скоринг с переопределенными признаками без сохранения
+// @Summary      What-if скоринг
+// @Description  Накладывает переопределения признаков на сохраненные признаки клиента и возвращает результат рядом с базовым расчетом. Клиент и история скорингов не изменяются. Значение null сбрасывает признак к значению по умолчанию.
+// @Tags         scoring
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int                    true  "Client ID"
+// @Param        input  body  dto.SimulationRequest  true  "Переопределения признаков"
+// @Param        breakdown  query     bool  false  "Включить детализацию расчета лимита"
+// @Success      200  {object}  dto.SimulationResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse  "ML-сервис недоступен, см. заголовок Retry-After"
+// @Router       /api/clients/{id}/scoring/simulate [post]
+func (h *ClientHandler) SimulateScoring(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+
+	var req dto.SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateSimulationRequest(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	result, err := h.scoringService.SimulateScoring(r.Context(), id, &req)
+	if err != nil {
+		var validationErr *domainerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.respondDomainValidation(w, validationErr)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrClientNotFound) {
+			h.respondError(w, http.StatusNotFound, "client not found")
+			return
+		}
+		if errors.Is(err, domainerrors.ErrMLServiceUnavailable) {
+			h.logger.Warn("ML service unavailable", "id", id, "error", err)
+			h.respondMLUnavailable(w, err)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrNoFeatures) || errors.Is(err, domainerrors.ErrInsufficientData) {
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "insufficient data for scoring", Message: err.Error()})
+			return
+		}
+		h.logger.Error("Failed to simulate scoring", "id", id, "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to simulate scoring")
+		return
+	}
+
+	stripBreakdown(r, result.Baseline, result.Simulated)
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// GetScoringHistory возвращает историю скорингов клиента
+// @Summary      История скорингов клиента
+// @Description  Возвращает сохраненные результаты скоринга клиента, от новых к старым, с пагинацией (offset-based)
+// @Tags         scoring
+// @Produce      json
+// @Param        id      path      int  true   "Client ID"
+// @Param        limit   query     int  false  "Количество записей (по умолчанию 100, макс 1000)"
+// @Param        offset  query     int  false  "Смещение (по умолчанию 0)"
+// @Success      200  {object}  dto.ScoringHistoryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/clients/{id}/scorings [get]
+func (h *ClientHandler) GetScoringHistory(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+
+	history, err := h.scoringService.GetScoringHistory(r.Context(), id, limit, offset)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrClientNotFound) {
+			h.respondError(w, http.StatusNotFound, "client not found")
+			return
+		}
+		h.logger.Error("Failed to get scoring history", "id", id, "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to get scoring history")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, history)
+}
+
+// GetLatestScoring возвращает последний сохраненный скоринг без обращения к ML
+// @Summary      Последний скоринг клиента
+// @Description  Возвращает последний сохраненный результат скоринга без повторного вызова ML-сервиса
+// @Tags         scoring
+// @Produce      json
+// @Param        id   path      int  true  "Client ID"
+// @Success      200  {object}  dto.ScoringRecordResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/clients/{id}/scorings/latest [get]
+func (h *ClientHandler) GetLatestScoring(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+
+	result, err := h.scoringService.GetLatestScoring(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrClientNotFound) {
+			h.respondError(w, http.StatusNotFound, "client not found")
+			return
+		}
+		if errors.Is(err, domainerrors.ErrScoringNotFound) {
+			h.respondError(w, http.StatusNotFound, "scoring result not found")
+			return
+		}
+		h.logger.Error("Failed to get latest scoring", "id", id, "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to get latest scoring")
+		return
+	}
+
 	h.respondJSON(w, http.StatusOK, result)
 }
 
@@ -151,6 +401,7 @@ func (h *ClientHandler) CalculateScoring(w http.ResponseWriter, r *http.Request)
 // @Param        input body dto.CreateClientRequest true "Данные клиента"
 // @Success      201  {object}  dto.ClientResponse
 // @Failure      400  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
 // @Router       /api/clients [post]
 func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
@@ -162,8 +413,22 @@ func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if errs := validateCreateClientRequest(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
 	client, err := h.clientService.CreateClient(r.Context(), &req)
 	if err != nil {
+		var validationErr *domainerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.respondDomainValidation(w, validationErr)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrInvalidInput) || errors.Is(err, domainerrors.ErrInvalidFormat) {
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid client data", Message: err.Error()})
+			return
+		}
 		h.logger.Error("Failed to create client", "error", err)
 		h.respondError(w, http.StatusInternalServerError, "failed to create client")
 		return
@@ -190,6 +455,7 @@ func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 // @Success      200  {object}  dto.ClientResponse
 // @Failure      400  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
 // @Router       /api/clients/{id} [put]
 func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request) {
@@ -208,12 +474,26 @@ func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if errs := validateUpdateClientRequest(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
 	client, err := h.clientService.UpdateClient(r.Context(), id, &req)
 	if err != nil {
 		if errors.Is(err, domainerrors.ErrClientNotFound) {
 			h.respondError(w, http.StatusNotFound, "client not found")
 			return
 		}
+		var validationErr *domainerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.respondDomainValidation(w, validationErr)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrInvalidInput) || errors.Is(err, domainerrors.ErrInvalidFormat) {
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid client data", Message: err.Error()})
+			return
+		}
 		h.logger.Error("Failed to update client", "id", id, "error", err)
 		h.respondError(w, http.StatusInternalServerError, "failed to update client")
 		return
@@ -274,20 +554,7 @@ func (h *ClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
 // @Failure      500  {object}  dto.ErrorResponse
 // @Router       /api/clients [get]
 func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
-	limit := 100
-	offset := 0
-
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
-			limit = parsedLimit
-		}
-	}
-
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
-	}
+	limit, offset := parsePagination(r)
 
 	clients, err := h.clientService.ListClients(r.Context(), limit, offset)
 	if err != nil {
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+type DriftHandler struct {
+	baseHandler
+	driftService interfaces.DriftService
+}
+
+func NewDriftHandler(driftService interfaces.DriftService, logger interfaces.Logger) *DriftHandler {
+	return &DriftHandler{
+		baseHandler:  baseHandler{logger: logger.With("component", "DriftHandler")},
+		driftService: driftService,
+	}
+}
+
+// GetDrift возвращает отчет о дрейфе признаков и прогнозов
+// @Summary      Дрейф данных
+// @Description  PSI и изменение доли пропусков (импутированных значений) по каждому числовому признаку и по прогнозу дохода за последние drift.window_days дней относительно эталона. Признаки отсортированы по убыванию PSI, превышения порогов помечены alert и пишутся в лог.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.DriftReport
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/drift [get]
+func (h *DriftHandler) GetDrift(w http.ResponseWriter, r *http.Request) {
+	report, err := h.driftService.GetDrift(r.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDriftReferenceNotFound):
+			h.respondJSON(w, http.StatusNotFound, dto.ErrorResponse{Error: "drift reference not found", Message: err.Error()})
+		case errors.Is(err, domainerrors.ErrNoScoringSnapshots):
+			h.respondJSON(w, http.StatusConflict, dto.ErrorResponse{Error: "no scorings in drift window", Message: err.Error()})
+		default:
+			h.logger.Error("Failed to compute drift", "error", err)
+			h.respondError(w, http.StatusInternalServerError, "failed to compute drift")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// BuildReference строит эталон для мониторинга дрейфа
+// @Summary      Эталон дрейфа
+// @Description  Строит эталонные распределения (квантильные бины и доля пропусков) по скорингам за период и заменяет ими прежний эталон. По умолчанию период - последние 30 дней.
+// @Tags         admin
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (DD-MM-YYYY)"
+// @Param        to    query     string  false  "Конец периода включительно (DD-MM-YYYY)"
+// @Success      201  {object}  dto.DriftReferenceResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/drift/reference [post]
+func (h *DriftHandler) BuildReference(w http.ResponseWriter, r *http.Request) {
+	from, to, validationErrs := parseDateRange(r, 30, time.Now())
+	if len(validationErrs) > 0 {
+		h.respondValidationErrors(w, validationErrs)
+		return
+	}
+
+	reference, err := h.driftService.BuildReference(r.Context(), from, to)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrNoScoringSnapshots) {
+			h.respondValidationErrors(w, []dto.ValidationError{{
+				Field:   "from",
+				Rule:    "has_scorings",
+				Message: "no scorings in the selected period",
+			}})
+			return
+		}
+		h.logger.Error("Failed to build drift reference", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to build drift reference")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, reference)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+type FeatureHandler struct {
+	baseHandler
+	schema *models.FeatureSchema
+}
+
+func NewFeatureHandler(schema *models.FeatureSchema, logger interfaces.Logger) *FeatureHandler {
+	return &FeatureHandler{
+		baseHandler: baseHandler{logger: logger.With("component", "FeatureHandler")},
+		schema:      schema,
+	}
+}
+
+// GetSchema возвращает схему признаков ML-модели
+// @Summary      Схема признаков
+// @Description  Возвращает версионированную схему признаков: тип (numeric/categorical/flag), допустимый диапазон или список значений, значение по умолчанию для заполнения пропусков, описание и версию модели
+// @Tags         features
+// @Produce      json
+// @Success      200  {object}  models.FeatureSchema
+// @Router       /api/features/schema [get]
+func (h *FeatureHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, h.schema)
+}
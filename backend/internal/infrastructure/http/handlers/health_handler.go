@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+type HealthHandler struct {
+	baseHandler
+	healthService interfaces.HealthService
+	version       string
+}
+
+func NewHealthHandler(healthService interfaces.HealthService, version string, logger interfaces.Logger) *HealthHandler {
+	return &HealthHandler{
+		baseHandler:   baseHandler{logger: logger.With("component", "HealthHandler")},
+		healthService: healthService,
+		version:       version,
+	}
+}
+
+// Livez сообщает, что процесс жив
+// @Summary      Liveness-проба
+// @Description  Отвечает 200, пока процесс обрабатывает запросы. Зависимости не проверяются.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.LivenessResponse
+// @Router       /livez [get]
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, dto.LivenessResponse{Status: dto.StatusAlive, Version: h.version})
+}
+
+// Readyz проверяет готовность сервиса принимать трафик
+// @Summary      Readiness-проба
+// @Description  Пингует пул соединений Postgres и вызывает health check ML-сервиса с таймаутом health.timeout. Возвращает статус и задержку каждой зависимости и версию сборки; 503, если недоступна обязательная зависимость.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.ReadinessResponse
+// @Failure      503  {object}  dto.ReadinessResponse
+// @Router       /readyz [get]
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.healthService.Readiness(r.Context())
+
+	status := http.StatusOK
+	if readiness.Status != dto.StatusReady {
+		status = http.StatusServiceUnavailable
+	}
+	h.respondJSON(w, status, readiness)
+}
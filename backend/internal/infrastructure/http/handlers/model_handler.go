@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+)
+
+type ModelHandler struct {
+	baseHandler
+	comparisonService interfaces.ModelComparisonService
+}
+
+func NewModelHandler(comparisonService interfaces.ModelComparisonService, logger interfaces.Logger) *ModelHandler {
+	return &ModelHandler{
+		baseHandler:       baseHandler{logger: logger.With("component", "ModelHandler")},
+		comparisonService: comparisonService,
+	}
+}
+
+// GetComparison сравнивает варианты модели champion/challenger
+// @Summary      Сравнение вариантов модели
+// @Description  Настроенные варианты модели и их доля трафика, число скорингов и средние прогноз и лимит по боевым вариантам, а также расхождение теневых вариантов с боевым на одних и тех же скорингах: доля согласных прогнозов (относительная разница не больше ml.routing.agreement_tolerance), средняя и средняя абсолютная разница прогноза и лимита (challenger - champion), доли скорингов с большим и меньшим лимитом. По умолчанию последние 30 дней.
+// @Tags         admin
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (DD-MM-YYYY)"
+// @Param        to    query     string  false  "Конец периода включительно (DD-MM-YYYY)"
+// @Success      200  {object}  dto.ModelComparisonResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/models/comparison [get]
+func (h *ModelHandler) GetComparison(w http.ResponseWriter, r *http.Request) {
+	from, to, validationErrs := parseDateRange(r, 30, time.Now())
+	if len(validationErrs) > 0 {
+		h.respondValidationErrors(w, validationErrs)
+		return
+	}
+
+	comparison, err := h.comparisonService.GetComparison(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error("Failed to compare model variants", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to compare model variants")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, comparison)
+}
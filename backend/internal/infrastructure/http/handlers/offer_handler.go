@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/go-chi/chi/v5"
+)
+
+type OfferHandler struct {
+	baseHandler
+	offerService interfaces.OfferService
+}
+
+func NewOfferHandler(offerService interfaces.OfferService, logger interfaces.Logger) *OfferHandler {
+	return &OfferHandler{
+		baseHandler:  baseHandler{logger: logger.With("component", "OfferHandler")},
+		offerService: offerService,
+	}
+}
+
+// GetOffers рассчитывает кредитные предложения для суммы и срока
+// @Summary      Кредитные предложения
+// @Description  Проверяет сумму относительно лимитов клиента из последнего скоринга (при его отсутствии скоринг считается заново) и возвращает по каждому продукту ставку, ежемесячный платеж, переплату, ПДН и аннуитетный график платежей
+// @Tags         offers
+// @Produce      json
+// @Param        id       path      int     true   "Client ID"
+// @Param        amount   query     number  true   "Сумма кредита"
+// @Param        term     query     int     true   "Срок в месяцах, не больше 600"
+// @Param        product  query     string  false  "Тип продукта (по умолчанию все)"
+// @Success      200  {object}  dto.OffersResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ErrorResponse  "Сумма выше лимита или нет подходящего тарифа"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse  "ML-сервис недоступен, см. заголовок Retry-After"
+// @Router       /api/clients/{id}/offers [get]
+func (h *OfferHandler) GetOffers(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+
+	query := r.URL.Query()
+	var validationErrs []dto.ValidationError
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	// ParseFloat принимает NaN и Inf, которые не пройдут ни одно сравнение и не кодируются в JSON
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		validationErrs = append(validationErrs, dto.ValidationError{Field: "amount", Rule: "gt", Message: "amount must be a positive number"})
+	}
+	term, err := strconv.Atoi(query.Get("term"))
+	switch {
+	case err != nil || term <= 0:
+		validationErrs = append(validationErrs, dto.ValidationError{Field: "term", Rule: "gt", Message: "term must be a positive number of months"})
+	case term > dto.MaxOfferTerm:
+		validationErrs = append(validationErrs, dto.ValidationError{Field: "term", Rule: "lte", Message: fmt.Sprintf("term must not exceed %d months", dto.MaxOfferTerm)})
+	}
+	if len(validationErrs) > 0 {
+		h.respondValidationErrors(w, validationErrs)
+		return
+	}
+
+	req := &dto.OfferRequest{
+		Amount:  amount,
+		Term:    term,
+		Product: query.Get("product"),
+	}
+
+	result, err := h.offerService.GetOffers(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrClientNotFound):
+			h.respondError(w, http.StatusNotFound, "client not found")
+		case errors.Is(err, domainerrors.ErrInvalidInput):
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid offer request", Message: err.Error()})
+		case errors.Is(err, domainerrors.ErrAmountExceedsLimit):
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "amount exceeds credit limit", Message: err.Error()})
+		case errors.Is(err, domainerrors.ErrNoMatchingRate):
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "no matching rate", Message: err.Error()})
+		case errors.Is(err, domainerrors.ErrNoFeatures), errors.Is(err, domainerrors.ErrInsufficientData):
+			h.respondJSON(w, http.StatusUnprocessableEntity, dto.ErrorResponse{Error: "insufficient data for scoring", Message: err.Error()})
+		case errors.Is(err, domainerrors.ErrMLServiceUnavailable):
+			h.logger.Warn("ML service unavailable", "id", id, "error", err)
+			h.respondMLUnavailable(w, err)
+		default:
+			h.logger.Error("Failed to calculate offers", "id", id, "error", err)
+			h.respondError(w, http.StatusInternalServerError, "failed to calculate offers")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
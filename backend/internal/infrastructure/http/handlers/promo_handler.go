@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type PromoHandler struct {
+	baseHandler
+	promoService interfaces.PromoService
+}
+
+func NewPromoHandler(promoService interfaces.PromoService, logger interfaces.Logger) *PromoHandler {
+	return &PromoHandler{
+		baseHandler:  baseHandler{logger: logger.With("component", "PromoHandler")},
+		promoService: promoService,
+	}
+}
+
+// ListCategories возвращает каталог промо
+// @Summary      Каталог промо
+// @Description  Возвращает категории промо-предложений с диапазонами дохода, отсортированные по sort_order
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.PromoCategoryResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos [get]
+func (h *PromoHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.promoService.ListCategories(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list promo categories", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to list promo categories")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, categories)
+}
+
+// GetCategory возвращает категорию промо по ID
+// @Summary      Категория промо
+// @Description  Возвращает категорию промо-предложений с диапазонами дохода
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  dto.PromoCategoryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos/{id} [get]
+func (h *PromoHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.promoService.GetCategory(r.Context(), id)
+	if err != nil {
+		h.respondPromoError(w, id, err, "failed to get promo category")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, category)
+}
+
+// CreateCategory создает категорию промо
+// @Summary      Создание категории промо
+// @Description  Создает категорию с диапазонами дохода. Диапазоны [min_income, max_income) не должны пересекаться и оставлять разрывы.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.PromoCategoryRequest true "Категория и диапазоны"
+// @Success      201  {object}  dto.PromoCategoryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos [post]
+func (h *PromoHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req dto.PromoCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateStruct(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	category, err := h.promoService.CreateCategory(r.Context(), &req)
+	if err != nil {
+		h.respondPromoError(w, 0, err, "failed to create promo category")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, category)
+}
+
+// UpdateCategory заменяет категорию промо
+// @Summary      Обновление категории промо
+// @Description  Обновляет категорию и ее диапазоны дохода: диапазоны с id обновляются, без id создаются, не переданные удаляются
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                       true  "Category ID"
+// @Param        input body  dto.PromoCategoryRequest  true  "Категория и диапазоны"
+// @Success      200  {object}  dto.PromoCategoryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos/{id} [put]
+func (h *PromoHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.PromoCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateStruct(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	category, err := h.promoService.UpdateCategory(r.Context(), id, &req)
+	if err != nil {
+		h.respondPromoError(w, id, err, "failed to update promo category")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, category)
+}
+
+// DeleteCategory удаляет категорию промо
+// @Summary      Удаление категории промо
+// @Description  Удаляет категорию вместе с ее диапазонами дохода
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  dto.SuccessResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos/{id} [delete]
+func (h *PromoHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.promoService.DeleteCategory(r.Context(), id); err != nil {
+		h.respondPromoError(w, id, err, "failed to delete promo category")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.SuccessResponse{Message: "promo category deleted successfully"})
+}
+
+// ListCampaigns возвращает промо-кампании
+// @Summary      Промо-кампании
+// @Description  Возвращает все промо-кампании, включая завершенные и отключенные, с числом выданных предложений
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.PromoCampaignResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/campaigns [get]
+func (h *PromoHandler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := h.promoService.ListCampaigns(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list promo campaigns", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to list promo campaigns")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, campaigns)
+}
+
+// GetCampaign возвращает промо-кампанию по ID
+// @Summary      Промо-кампания
+// @Description  Возвращает промо-кампанию с условиями таргетинга
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Campaign ID"
+// @Success      200  {object}  dto.PromoCampaignResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/campaigns/{id} [get]
+func (h *PromoHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+
+	campaign, err := h.promoService.GetCampaign(r.Context(), id)
+	if err != nil {
+		h.respondPromoError(w, id, err, "failed to get promo campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, campaign)
+}
+
+// CreateCampaign создает промо-кампанию
+// @Summary      Создание промо-кампании
+// @Description  Создает кампанию в категории промо. В период действия кампания с наибольшим приоритетом, у которой выполнены все условия таргетинга и не исчерпан лимит выдач, заменяет предложение по диапазону дохода.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        input body dto.PromoCampaignRequest true "Кампания и условия таргетинга"
+// @Success      201  {object}  dto.PromoCampaignResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/campaigns [post]
+func (h *PromoHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var req dto.PromoCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateStruct(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	campaign, err := h.promoService.CreateCampaign(r.Context(), &req)
+	if err != nil {
+		h.respondPromoError(w, 0, err, "failed to create promo campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, campaign)
+}
+
+// UpdateCampaign заменяет промо-кампанию
+// @Summary      Обновление промо-кампании
+// @Description  Обновляет кампанию целиком. Счетчик выданных предложений сохраняется.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                       true  "Campaign ID"
+// @Param        input body  dto.PromoCampaignRequest  true  "Кампания и условия таргетинга"
+// @Success      200  {object}  dto.PromoCampaignResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/campaigns/{id} [put]
+func (h *PromoHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.PromoCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateStruct(&req); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	campaign, err := h.promoService.UpdateCampaign(r.Context(), id, &req)
+	if err != nil {
+		h.respondPromoError(w, id, err, "failed to update promo campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, campaign)
+}
+
+// DeleteCampaign удаляет промо-кампанию
+// @Summary      Удаление промо-кампании
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Campaign ID"
+// @Success      200  {object}  dto.SuccessResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/campaigns/{id} [delete]
+func (h *PromoHandler) DeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.promoService.DeleteCampaign(r.Context(), id); err != nil {
+		h.respondPromoError(w, id, err, "failed to delete promo campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.SuccessResponse{Message: "promo campaign deleted successfully"})
+}
+
+// AcceptPromo фиксирует согласие клиента на промо
+// @Summary      Клиент принял промо
+// @Description  Записывает согласие клиента на последний показ промо. promoId - id рекомендации из скоринга (band-<id> или campaign-<id>).
+// @Tags         promos
+// @Produce      json
+// @Param        id       path      int     true  "Client ID"
+// @Param        promoId  path      string  true  "Promo ID"
+// @Success      200  {object}  dto.PromoImpressionResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse  "Промо не показывалось клиенту"
+// @Failure      409  {object}  dto.ErrorResponse  "Реакция на показ уже записана"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/clients/{id}/promos/{promoId}/accept [post]
+func (h *PromoHandler) AcceptPromo(w http.ResponseWriter, r *http.Request) {
+	h.recordOutcome(w, r, models.ImpressionAccepted)
+}
+
+// DeclinePromo фиксирует отказ клиента от промо
+// @Summary      Клиент отказался от промо
+// @Description  Записывает отказ клиента от последнего показа промо. promoId - id рекомендации из скоринга (band-<id> или campaign-<id>).
+// @Tags         promos
+// @Produce      json
+// @Param        id       path      int     true  "Client ID"
+// @Param        promoId  path      string  true  "Promo ID"
+// @Success      200  {object}  dto.PromoImpressionResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse  "Промо не показывалось клиенту"
+// @Failure      409  {object}  dto.ErrorResponse  "Реакция на показ уже записана"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/clients/{id}/promos/{promoId}/decline [post]
+func (h *PromoHandler) DeclinePromo(w http.ResponseWriter, r *http.Request) {
+	h.recordOutcome(w, r, models.ImpressionDeclined)
+}
+
+func (h *PromoHandler) recordOutcome(w http.ResponseWriter, r *http.Request, status string) {
+	idStr := chi.URLParam(r, "id")
+	clientID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+	promoID := chi.URLParam(r, "promoId")
+
+	impression, err := h.promoService.RecordOutcome(r.Context(), clientID, promoID, status)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidClientID):
+			h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		case errors.Is(err, domainerrors.ErrImpressionNotFound):
+			h.respondError(w, http.StatusNotFound, "promo was not shown to client")
+		case errors.Is(err, domainerrors.ErrOutcomeAlreadyRecorded):
+			h.respondJSON(w, http.StatusConflict, dto.ErrorResponse{Error: "promo outcome already recorded", Message: err.Error()})
+		default:
+			h.logger.Error("Failed to record promo outcome", "client_id", clientID, "promo_id", promoID, "error", err)
+			h.respondError(w, http.StatusInternalServerError, "failed to record promo outcome")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, impression)
+}
+
+// GetStats возвращает конверсию промо
+// @Summary      Конверсия промо
+// @Description  Показы, принятия, отказы и конверсия (accepted / impressions) по каждому промо и диапазону прогноза дохода (promo.stats_income_bands) за период. По умолчанию - последние 30 дней.
+// @Tags         admin
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (DD-MM-YYYY)"
+// @Param        to    query     string  false  "Конец периода включительно (DD-MM-YYYY)"
+// @Success      200  {object}  dto.PromoStatsResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/promos/stats [get]
+func (h *PromoHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	from, to, validationErrs := parseDateRange(r, 30, time.Now())
+	if len(validationErrs) > 0 {
+		h.respondValidationErrors(w, validationErrs)
+		return
+	}
+
+	stats, err := h.promoService.GetStats(r.Context(), from, to)
+	if err != nil {
+		var validationErr *domainerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.respondDomainValidation(w, validationErr)
+			return
+		}
+		h.logger.Error("Failed to get promo stats", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to get promo stats")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, stats)
+}
+
+func (h *PromoHandler) parseCampaignID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid promo campaign ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid promo campaign ID")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *PromoHandler) parseCategoryID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid promo category ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid promo category ID")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *PromoHandler) respondPromoError(w http.ResponseWriter, id int64, err error, message string) {
+	var validationErr *domainerrors.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		h.respondDomainValidation(w, validationErr)
+	case errors.Is(err, domainerrors.ErrPromoNotFound):
+		h.respondError(w, http.StatusNotFound, "promo category not found")
+	case errors.Is(err, domainerrors.ErrCampaignNotFound):
+		h.respondError(w, http.StatusNotFound, "promo campaign not found")
+	case errors.Is(err, domainerrors.ErrPromoAlreadyExists):
+		h.respondJSON(w, http.StatusConflict, dto.ErrorResponse{Error: "promo category already exists", Message: err.Error()})
+	default:
+		h.logger.Error("Promo catalog request failed", "id", id, "error", err)
+		h.respondError(w, http.StatusInternalServerError, message)
+	}
+}
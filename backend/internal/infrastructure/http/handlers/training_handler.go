@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/go-chi/chi/v5"
+)
+
+type TrainingHandler struct {
+	baseHandler
+	trainingService interfaces.TrainingService
+}
+
+func NewTrainingHandler(trainingService interfaces.TrainingService, logger interfaces.Logger) *TrainingHandler {
+	return &TrainingHandler{
+		baseHandler:     baseHandler{logger: logger.With("component", "TrainingHandler")},
+		trainingService: trainingService,
+	}
+}
+
+// ConfirmIncome сохраняет подтвержденный доход клиента
+// @Summary      Подтверждение дохода
+// @Description  Сохраняет фактический доход клиента и размечает им признаки последнего скоринга. Размеченные примеры периодически отправляются в ML-сервис для дообучения.
+// @Tags         clients
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                            true  "Client ID"
+// @Param        input body  dto.IncomeConfirmationRequest  true  "Подтвержденный доход"
+// @Success      201  {object}  dto.IncomeConfirmationResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/clients/{id}/income-confirmation [post]
+func (h *TrainingHandler) ConfirmIncome(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid client ID", "id", idStr)
+		h.respondError(w, http.StatusBadRequest, "invalid client ID")
+		return
+	}
+
+	var req dto.IncomeConfirmationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errs := validateIncomeConfirmationRequest(&req, time.Now()); len(errs) > 0 {
+		h.respondValidationErrors(w, errs)
+		return
+	}
+
+	confirmation, err := h.trainingService.ConfirmIncome(r.Context(), id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrClientNotFound):
+			h.respondError(w, http.StatusNotFound, "client not found")
+		case errors.Is(err, domainerrors.ErrInvalidClientID), errors.Is(err, domainerrors.ErrInvalidFormat):
+			h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{Error: "invalid income confirmation", Message: err.Error()})
+		default:
+			h.logger.Error("Failed to confirm income", "id", id, "error", err)
+			h.respondError(w, http.StatusInternalServerError, "failed to confirm income")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, confirmation)
+}
+
+// GetDeliveryStats возвращает состояние выгрузки примеров для дообучения
+// @Summary      Выгрузка примеров для дообучения
+// @Description  Число размеченных примеров по статусам отправки в ML-сервис и время последней доставки
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.TrainingDeliveryStatsResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/training/stats [get]
+func (h *TrainingHandler) GetDeliveryStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.trainingService.GetDeliveryStats(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get training delivery stats", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to get training delivery stats")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, stats)
+}
+
+// GetModelQuality возвращает отчет о качестве прогноза дохода
+// @Summary      Качество модели
+// @Description  MAE, MAPE (%) и bias (прогноз - факт) прогноза дохода относительно подтвержденного дохода в целом и в разбивке по версии модели, диапазону подтвержденного дохода, региону и возрастной группе. Период - по дате подтверждения дохода, по умолчанию последние 90 дней.
+// @Tags         admin
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (DD-MM-YYYY)"
+// @Param        to    query     string  false  "Конец периода включительно (DD-MM-YYYY)"
+// @Success      200  {object}  dto.ModelQualityResponse
+// @Failure      422  {object}  dto.ValidationErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/admin/model-quality [get]
+func (h *TrainingHandler) GetModelQuality(w http.ResponseWriter, r *http.Request) {
+	from, to, validationErrs := parseDateRange(r, 90, time.Now())
+	if len(validationErrs) > 0 {
+		h.respondValidationErrors(w, validationErrs)
+		return
+	}
+
+	report, err := h.trainingService.GetModelQuality(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error("Failed to build model quality report", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "failed to build model quality report")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
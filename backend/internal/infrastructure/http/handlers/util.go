@@ -2,12 +2,26 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
 )
 
-func (h *ClientHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+// baseHandler общие для всех хендлеров логгер и хелперы ответов
+type baseHandler struct {
+	logger interfaces.Logger
+}
+
+// defaultRetryAfter подсказка клиенту, если ошибка не несет своей
+const defaultRetryAfter = 30 * time.Second
+
+func (h *baseHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
@@ -15,6 +29,103 @@ func (h *ClientHandler) respondJSON(w http.ResponseWriter, status int, data inte
 	}
 }
 
-func (h *ClientHandler) respondError(w http.ResponseWriter, status int, message string) {
+func (h *baseHandler) respondError(w http.ResponseWriter, status int, message string) {
 	h.respondJSON(w, status, dto.ErrorResponse{Error: message})
 }
+
+// respondValidationErrors отвечает 422 со списком ошибок по полям
+func (h *baseHandler) respondValidationErrors(w http.ResponseWriter, errs []dto.ValidationError) {
+	h.respondJSON(w, http.StatusUnprocessableEntity, dto.ValidationErrorResponse{
+		Error:  "validation failed",
+		Errors: errs,
+	})
+}
+
+// respondDomainValidation отвечает 422, переводя доменную ValidationError в DTO
+func (h *baseHandler) respondDomainValidation(w http.ResponseWriter, err *domainerrors.ValidationError) {
+	errs := make([]dto.ValidationError, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		errs = append(errs, dto.ValidationError{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+		})
+	}
+	h.respondValidationErrors(w, errs)
+}
+
+// respondMLUnavailable отвечает 503 с заголовком Retry-After (в секундах)
+func (h *baseHandler) respondMLUnavailable(w http.ResponseWriter, err error) {
+	retryAfter := defaultRetryAfter
+	var retryErr *domainerrors.RetryAfterError
+	if errors.As(err, &retryErr) && retryErr.RetryAfter > 0 {
+		retryAfter = retryErr.RetryAfter
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	h.respondJSON(w, http.StatusServiceUnavailable, dto.ErrorResponse{
+		Error: "ML service is unavailable",
+		Code:  "ML_UNAVAILABLE",
+	})
+}
+
+// parsePagination читает limit/offset из query (limit по умолчанию 100, макс 1000)
+func parsePagination(r *http.Request) (limit, offset int) {
+	limit = 100
+	offset = 0
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			limit = parsedLimit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	return limit, offset
+}
+
+// parseDateRange читает период from/to (DD-MM-YYYY, включительно) из query.
+// По умолчанию - последние defaultDays дней до сегодня. Возвращает [from, to),
+// где to - начало дня, следующего за последним днем периода.
+func parseDateRange(r *http.Request, defaultDays int, now time.Time) (from, to time.Time, errs []dto.ValidationError) {
+	query := r.URL.Query()
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.ParseInLocation(dto.DateFormat, value, now.Location())
+		if err != nil {
+			errs = append(errs, dto.ValidationError{Field: "to", Rule: "datetime", Message: "to must be in DD-MM-YYYY format"})
+		}
+		last = parsed
+	}
+	from = last.AddDate(0, 0, 1-defaultDays)
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.ParseInLocation(dto.DateFormat, value, now.Location())
+		if err != nil {
+			errs = append(errs, dto.ValidationError{Field: "from", Rule: "datetime", Message: "from must be in DD-MM-YYYY format"})
+		}
+		from = parsed
+	}
+	if len(errs) == 0 && last.Before(from) {
+		errs = append(errs, dto.ValidationError{Field: "to", Rule: "gtefield", Message: "to must not be before from"})
+	}
+
+	return from, last.AddDate(0, 0, 1), errs
+}
+
+// stripBreakdown убирает детализацию расчета лимита, если она не запрошена через ?breakdown=true
+func stripBreakdown(r *http.Request, responses ...*dto.ScoringResponse) {
+	if include, _ := strconv.ParseBool(r.URL.Query().Get("breakdown")); include {
+		return
+	}
+	for _, response := range responses {
+		if response != nil {
+			response.Breakdown = nil
+		}
+	}
+}
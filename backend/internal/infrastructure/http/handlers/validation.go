@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	minClientAge       = 14
+	maxClientAge       = 120
+	maxFeatureNameSize = 200
+)
+
+var requestValidator = newRequestValidator()
+
+func newRequestValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validateCreateClientRequest проверяет теги validate, дату рождения и признаки
+func validateCreateClientRequest(req *dto.CreateClientRequest) []dto.ValidationError {
+	errs := validateStruct(req)
+	if req.BirthDate != "" {
+		errs = append(errs, validateBirthDate(req.BirthDate, time.Now())...)
+	}
+	errs = append(errs, validateFeatures(req.Features)...)
+	return errs
+}
+
+func validateUpdateClientRequest(req *dto.UpdateClientRequest) []dto.ValidationError {
+	errs := validateStruct(req)
+	if req.BirthDate != "" {
+		errs = append(errs, validateBirthDate(req.BirthDate, time.Now())...)
+	}
+	errs = append(errs, validateFeatures(req.Features)...)
+	return errs
+}
+
+func validateStruct(req interface{}) []dto.ValidationError {
+	err := requestValidator.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return []dto.ValidationError{{Field: "", Rule: "invalid", Message: err.Error()}}
+	}
+
+	result := make([]dto.ValidationError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		result = append(result, dto.ValidationError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: validationMessage(fe),
+		})
+	}
+	return result
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters long", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters long", fe.Field(), fe.Param())
+	case "datetime":
+		return fmt.Sprintf("%s must be a valid date in format DD-MM-YYYY", fe.Field())
+	default:
+		return fmt.Sprintf("%s failed %s validation", fe.Field(), fe.Tag())
+	}
+}
+
+func validateApplicantScoringRequest(req *dto.ApplicantScoringRequest) []dto.ValidationError {
+	return validateCreateClientRequest((*dto.CreateClientRequest)(req))
+}
+
+func validateSimulationRequest(req *dto.SimulationRequest) []dto.ValidationError {
+	if len(req.Overrides) == 0 {
+		return []dto.ValidationError{{
+			Field:   "overrides",
+			Rule:    "required",
+			Message: "overrides must contain at least one feature",
+		}}
+	}
+	return nil
+}
+
+// validateIncomeConfirmationRequest проверяет теги validate и что доход подтвержден не в будущем
+func validateIncomeConfirmationRequest(req *dto.IncomeConfirmationRequest, now time.Time) []dto.ValidationError {
+	errs := validateStruct(req)
+	if confirmedAt, err := time.Parse(dto.DateFormat, req.ConfirmedAt); err == nil && confirmedAt.After(now) {
+		errs = append(errs, dto.ValidationError{
+			Field:   "confirmed_at",
+			Rule:    "not_future",
+			Message: "confirmed_at cannot be in the future",
+		})
+	}
+	return errs
+}
+
+// validateBirthDate проверяет правдоподобность даты рождения.
+// Формат проверяется тегом datetime, здесь неразбираемая дата пропускается.
+func validateBirthDate(value string, now time.Time) []dto.ValidationError {
+	birthDate, err := time.Parse(dto.DateFormat, value)
+	if err != nil {
+		return nil
+	}
+
+	if birthDate.After(now) {
+		return []dto.ValidationError{{
+			Field:   "birth_date",
+			Rule:    "not_future",
+			Message: "birth_date cannot be in the future",
+		}}
+	}
+
+	age := dto.AgeAt(birthDate, now)
+	if age < minClientAge {
+		return []dto.ValidationError{{
+			Field:   "birth_date",
+			Rule:    "min_age",
+			Message: fmt.Sprintf("client must be at least %d years old", minClientAge),
+		}}
+	}
+	if age > maxClientAge {
+		return []dto.ValidationError{{
+			Field:   "birth_date",
+			Rule:    "max_age",
+			Message: fmt.Sprintf("client cannot be older than %d years", maxClientAge),
+		}}
+	}
+
+	return nil
+}
+
+// validateFeatures допускает только плоские значения: числа, строки и булевы флаги.
+// Признаки проверяются в порядке имен, чтобы ответ с ошибками был стабильным.
+func validateFeatures(features map[string]interface{}) []dto.ValidationError {
+	var errs []dto.ValidationError
+	for _, name := range slices.Sorted(maps.Keys(features)) {
+		value := features[name]
+		field := "features." + name
+
+		if strings.TrimSpace(name) == "" || len(name) > maxFeatureNameSize {
+			errs = append(errs, dto.ValidationError{
+				Field:   field,
+				Rule:    "name",
+				Message: fmt.Sprintf("feature name must be non-empty and at most %d characters long", maxFeatureNameSize),
+			})
+			continue
+		}
+
+		switch value.(type) {
+		case float64, string, bool:
+		case nil:
+			errs = append(errs, dto.ValidationError{
+				Field:   field,
+				Rule:    "type",
+				Message: fmt.Sprintf("feature %s cannot be null", name),
+			})
+		default:
+			errs = append(errs, dto.ValidationError{
+				Field:   field,
+				Rule:    "type",
+				Message: fmt.Sprintf("feature %s must be a number, string or boolean", name),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// LongTimeout таймаут для долгих маршрутов: отменяет контекст запроса через
+// timeout и продлевает дедлайн записи ответа, заданный server.write_timeout
+func LongTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withTimeout := chimiddleware.Timeout(timeout)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// дедлайн не продлевается, если writer его не поддерживает (например, в тестах)
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+			withTimeout.ServeHTTP(w, r)
+		})
+	}
+}
@@ -18,18 +18,43 @@ import (
 )
 
 type Server struct {
-	cfg           *config.Config
-	router        *chi.Mux
-	server        *http.Server
-	logger        interfaces.Logger
-	clientHandler *handlers.ClientHandler
+	cfg             *config.Config
+	router          *chi.Mux
+	server          *http.Server
+	logger          interfaces.Logger
+	clientHandler   *handlers.ClientHandler
+	featureHandler  *handlers.FeatureHandler
+	offerHandler    *handlers.OfferHandler
+	promoHandler    *handlers.PromoHandler
+	trainingHandler *handlers.TrainingHandler
+	driftHandler    *handlers.DriftHandler
+	healthHandler   *handlers.HealthHandler
+	modelHandler    *handlers.ModelHandler
 }
 
-func NewServer(cfg *config.Config, clientHandler *handlers.ClientHandler, logger interfaces.Logger) *Server {
+func NewServer(
+	cfg *config.Config,
+	clientHandler *handlers.ClientHandler,
+	featureHandler *handlers.FeatureHandler,
+	offerHandler *handlers.OfferHandler,
+	promoHandler *handlers.PromoHandler,
+	trainingHandler *handlers.TrainingHandler,
+	driftHandler *handlers.DriftHandler,
+	healthHandler *handlers.HealthHandler,
+	modelHandler *handlers.ModelHandler,
+	logger interfaces.Logger,
+) *Server {
 	s := &Server{
-		cfg:           cfg,
-		logger:        logger,
-		clientHandler: clientHandler,
+		cfg:             cfg,
+		logger:          logger,
+		clientHandler:   clientHandler,
+		featureHandler:  featureHandler,
+		offerHandler:    offerHandler,
+		promoHandler:    promoHandler,
+		trainingHandler: trainingHandler,
+		driftHandler:    driftHandler,
+		healthHandler:   healthHandler,
+		modelHandler:    modelHandler,
 	}
 
 	s.setupRouter()
@@ -53,7 +78,7 @@ func (s *Server) setupRouter() {
 		AllowedOrigins:   []string{"http://localhost:4000", "http://localhost:8080", "http://localhost:5173"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -63,24 +88,68 @@ func (s *Server) setupRouter() {
 	r.Use(middleware.Recovery(s.logger))
 	r.Use(middleware.LoggingMiddleware(s.logger))
 
-	r.Use(chimiddleware.Timeout(60 * time.Second))
-
-	r.Get("/health", s.healthCheckHandler)
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
-
-	r.Route("/api", func(r chi.Router) {
-		r.Route("/clients", func(r chi.Router) {
-			r.Get("/", s.clientHandler.ListClients)
-			r.Post("/", s.clientHandler.CreateClient)
-			r.Get("/search", s.clientHandler.SearchClients)
-			r.Post("/import", s.clientHandler.ImportClientsCSV)
-			r.Get("/{id}", s.clientHandler.GetClient)
-			r.Put("/{id}", s.clientHandler.UpdateClient)
-			r.Delete("/{id}", s.clientHandler.DeleteClient)
-			r.Get("/{id}/scoring", s.clientHandler.CalculateScoring)
+	r.Group(func(r chi.Router) {
+		r.Use(chimiddleware.Timeout(60 * time.Second))
+
+		r.Get("/health", s.healthCheckHandler)
+		r.Get("/livez", s.healthHandler.Livez)
+		r.Get("/readyz", s.healthHandler.Readyz)
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
+
+		r.Route("/api", func(r chi.Router) {
+			r.Route("/clients", func(r chi.Router) {
+				r.Get("/", s.clientHandler.ListClients)
+				r.Post("/", s.clientHandler.CreateClient)
+				r.Get("/search", s.clientHandler.SearchClients)
+				r.Post("/import", s.clientHandler.ImportClientsCSV)
+				r.Get("/{id}", s.clientHandler.GetClient)
+				r.Put("/{id}", s.clientHandler.UpdateClient)
+				r.Delete("/{id}", s.clientHandler.DeleteClient)
+				r.Get("/{id}/scoring", s.clientHandler.CalculateScoring)
+				r.Post("/{id}/scoring/simulate", s.clientHandler.SimulateScoring)
+				r.Get("/{id}/scorings", s.clientHandler.GetScoringHistory)
+				r.Get("/{id}/scorings/latest", s.clientHandler.GetLatestScoring)
+				r.Get("/{id}/offers", s.offerHandler.GetOffers)
+				r.Post("/{id}/promos/{promoId}/accept", s.promoHandler.AcceptPromo)
+				r.Post("/{id}/promos/{promoId}/decline", s.promoHandler.DeclinePromo)
+				r.Post("/{id}/income-confirmation", s.trainingHandler.ConfirmIncome)
+			})
+
+			r.Route("/scoring", func(r chi.Router) {
+				r.Post("/", s.clientHandler.ScoreApplicant)
+			})
+
+			r.Get("/features/schema", s.featureHandler.GetSchema)
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Route("/promos", func(r chi.Router) {
+					r.Get("/", s.promoHandler.ListCategories)
+					r.Post("/", s.promoHandler.CreateCategory)
+					r.Get("/stats", s.promoHandler.GetStats)
+					r.Get("/{id}", s.promoHandler.GetCategory)
+					r.Put("/{id}", s.promoHandler.UpdateCategory)
+					r.Delete("/{id}", s.promoHandler.DeleteCategory)
+				})
+				r.Get("/training/stats", s.trainingHandler.GetDeliveryStats)
+				r.Get("/model-quality", s.trainingHandler.GetModelQuality)
+				r.Get("/drift", s.driftHandler.GetDrift)
+				r.Post("/drift/reference", s.driftHandler.BuildReference)
+				r.Get("/models/comparison", s.modelHandler.GetComparison)
+				r.Route("/campaigns", func(r chi.Router) {
+					r.Get("/", s.promoHandler.ListCampaigns)
+					r.Post("/", s.promoHandler.CreateCampaign)
+					r.Get("/{id}", s.promoHandler.GetCampaign)
+					r.Put("/{id}", s.promoHandler.UpdateCampaign)
+					r.Delete("/{id}", s.promoHandler.DeleteCampaign)
+				})
+			})
 		})
 	})
 
+	// пакетный скоринг дольше общего таймаута, поэтому зарегистрирован вне группы
+	batchTimeout := time.Duration(s.cfg.Scoring.BatchTimeout) * time.Second
+	r.With(middleware.LongTimeout(batchTimeout)).Post("/api/scoring/batch", s.clientHandler.CalculateBatchScoring)
+
 	s.router = r
 }
 
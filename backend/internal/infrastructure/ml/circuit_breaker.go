@@ -0,0 +1,135 @@
+package ml
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerPolicy настройки circuit breaker для ML-сервиса.
+// FailureThreshold <= 0 отключает breaker.
+type BreakerPolicy struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+// circuitBreaker после FailureThreshold подряд неудачных запросов перестает
+// пропускать вызовы на OpenTimeout, затем пропускает один пробный запрос
+type circuitBreaker struct {
+	mu       sync.Mutex
+	policy   BreakerPolicy
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+func newCircuitBreaker(policy BreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// allow сообщает, можно ли выполнить запрос. Если нельзя, возвращает время,
+// через которое breaker снова начнет пропускать запросы.
+func (b *circuitBreaker) allow() (bool, time.Duration) {
+	if b.policy.FailureThreshold <= 0 {
+		return true, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		elapsed := b.now().Sub(b.openedAt)
+		if elapsed < b.policy.OpenTimeout {
+			return false, b.policy.OpenTimeout - elapsed
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true, 0
+	case breakerHalfOpen:
+		if b.probing {
+			return false, b.policy.OpenTimeout
+		}
+		b.probing = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// releaseProbe отпускает пробный запрос, завершившийся без ответа ML (отмена
+// вызывающим): breaker остается полуоткрытым и пропустит следующую пробу
+func (b *circuitBreaker) releaseProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.probing = false
+	}
+}
+
+// recordFailure возвращает true, если после этой ошибки breaker разомкнулся
+func (b *circuitBreaker) recordFailure() bool {
+	if b.policy.FailureThreshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.open()
+		return true
+	}
+
+	b.failures++
+	if b.state == breakerClosed && b.failures >= b.policy.FailureThreshold {
+		b.open()
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) open() {
+	b.state = breakerOpen
+	b.openedAt = b.now()
+	b.probing = false
+}
+
+func (b *circuitBreaker) currentState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
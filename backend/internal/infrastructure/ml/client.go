@@ -1,11 +1,9 @@
 package ml
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -20,9 +18,19 @@ type mlClient struct {
 	logger          interfaces.Logger
 	modelVersion    string
 	pipelineVersion string
+	retry           RetryPolicy
+	breakerPolicy   BreakerPolicy
+	breaker         *circuitBreaker
 }
 
-func NewMLClient(baseURL string, timeoutSeconds int, modelVersion, pipelineVersion string, logger interfaces.Logger) interfaces.MLService {
+func NewMLClient(
+	baseURL string,
+	timeoutSeconds int,
+	modelVersion, pipelineVersion string,
+	retry RetryPolicy,
+	breaker BreakerPolicy,
+	logger interfaces.Logger,
+) interfaces.MLService {
 	return &mlClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
@@ -31,6 +39,9 @@ func NewMLClient(baseURL string, timeoutSeconds int, modelVersion, pipelineVersi
 		logger:          logger.With("component", "MLClient"),
 		modelVersion:    modelVersion,
 		pipelineVersion: pipelineVersion,
+		retry:           retry,
+		breakerPolicy:   breaker,
+		breaker:         newCircuitBreaker(breaker),
 	}
 }
 
@@ -105,30 +116,15 @@ func (c *mlClient) PredictWithExplanation(ctx context.Context, features map[stri
 	url := fmt.Sprintf("%s/predict", c.baseURL)
 	c.logger.Info("[ML REQUEST] Sending POST request", "url", url, "base_url", c.baseURL, "payload_size", len(jsonData))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		c.logger.Error("Failed to create HTTP request", "error", err, "url", url)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	c.logger.Info("[ML REQUEST] Executing HTTP request", "method", "POST", "url", url)
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, http.MethodPost, url, jsonData)
 	if err != nil {
 		c.logger.Error("Failed to execute ML prediction request", "error", err, "url", url)
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	c.logger.Info("[ML RESPONSE] Received response", "status_code", resp.StatusCode, "url", url)
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("ML service returned error", "status", resp.StatusCode, "body", string(body))
-		return nil, fmt.Errorf("ML service returned status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var response predictResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		c.logger.Error("Failed to decode ML response", "error", err)
@@ -136,9 +132,11 @@ func (c *mlClient) PredictWithExplanation(ctx context.Context, features map[stri
 	}
 
 	mlResponse := &dto.MLScoringResponse{
-		Prediction:  response.Prediction,
-		Explanation: response.Explanation,
-		ID:          response.UID,
+		Prediction:      response.Prediction,
+		Explanation:     response.Explanation,
+		ID:              response.UID,
+		ModelVersion:    c.modelVersion,
+		PipelineVersion: c.pipelineVersion,
 	}
 
 	c.logger.Info("ML prediction with explanation completed", "prediction", mlResponse.Prediction, "uid", mlResponse.ID)
@@ -159,27 +157,13 @@ func (c *mlClient) SendTrainingData(ctx context.Context, data interface{}) error
 	}
 
 	url := fmt.Sprintf("%s/api/train", c.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		c.logger.Error("Failed to create training request", "error", err)
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, http.MethodPost, url, jsonData)
 	if err != nil {
 		c.logger.Error("Failed to send training data", "error", err)
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("ML service rejected training data", "status", resp.StatusCode, "body", string(body))
-		return fmt.Errorf("ML service returned status %d: %s", resp.StatusCode, string(body))
-	}
-
 	c.logger.Info("Training data sent successfully")
 	return nil
 }
@@ -0,0 +1,56 @@
+package ml
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// fallbackMLClient переключает предсказания на резервную реализацию,
+// когда основная возвращает ErrMLServiceUnavailable
+type fallbackMLClient struct {
+	primary  interfaces.MLService
+	fallback interfaces.MLService
+	logger   interfaces.Logger
+}
+
+func NewFallbackMLClient(primary, fallback interfaces.MLService, logger interfaces.Logger) interfaces.MLService {
+	return &fallbackMLClient{
+		primary:  primary,
+		fallback: fallback,
+		logger:   logger.With("component", "FallbackMLClient"),
+	}
+}
+
+func (c *fallbackMLClient) Predict(ctx context.Context, features map[string]interface{}) (*models.ScoringResult, error) {
+	result, err := c.primary.Predict(ctx, features)
+	if err != nil && errors.Is(err, domainerrors.ErrMLServiceUnavailable) {
+		c.logger.Warn("ML service unavailable, using fallback model", "error", err)
+		return c.fallback.Predict(ctx, features)
+	}
+	return result, err
+}
+
+func (c *fallbackMLClient) PredictWithExplanation(ctx context.Context, features map[string]interface{}) (*dto.MLScoringResponse, error) {
+	result, err := c.primary.PredictWithExplanation(ctx, features)
+	if err != nil && errors.Is(err, domainerrors.ErrMLServiceUnavailable) {
+		c.logger.Warn("ML service unavailable, using fallback model", "error", err)
+		result, err = c.fallback.PredictWithExplanation(ctx, features)
+		if result != nil {
+			result.Fallback = true
+		}
+	}
+	return result, err
+}
+
+func (c *fallbackMLClient) SendTrainingData(ctx context.Context, data interface{}) error {
+	return c.primary.SendTrainingData(ctx, data)
+}
+
+func (c *fallbackMLClient) HealthCheck(ctx context.Context) error {
+	return c.primary.HealthCheck(ctx)
+}
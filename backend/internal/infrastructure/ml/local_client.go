@@ -0,0 +1,115 @@
+package ml
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// localMLClient считает предсказание в процессе по JSON-дампу
+// LightGBM/XGBoost модели, без обращения к ML-сервису
+type localMLClient struct {
+	model           *treeEnsemble
+	modelVersion    string
+	pipelineVersion string
+	logger          interfaces.Logger
+}
+
+func NewLocalMLClient(modelPath string, baseScore float64, modelVersion, pipelineVersion string, logger interfaces.Logger) (interfaces.MLService, error) {
+	logger = logger.With("component", "LocalMLClient")
+
+	model, err := loadTreeEnsemble(modelPath, baseScore)
+	if err != nil {
+		logger.Error("Failed to load local model", "path", modelPath, "error", err)
+		return nil, fmt.Errorf("failed to load local model %s: %w", modelPath, err)
+	}
+
+	if model.version != "" && modelVersion == "" {
+		modelVersion = model.version
+	}
+
+	logger.Info("Local model loaded",
+		"path", modelPath,
+		"format", model.format,
+		"trees", len(model.trees),
+		"features", len(model.featureNames),
+		"model_version", modelVersion,
+	)
+
+	return &localMLClient{
+		model:           model,
+		modelVersion:    modelVersion,
+		pipelineVersion: pipelineVersion,
+		logger:          logger,
+	}, nil
+}
+
+func (c *localMLClient) Predict(ctx context.Context, features map[string]interface{}) (*models.ScoringResult, error) {
+	mlResponse, err := c.PredictWithExplanation(ctx, features)
+	if err != nil {
+		return nil, err
+	}
+
+	flatFactors := make(map[string]float64)
+	for _, group := range mlResponse.Explanation {
+		for k, v := range group {
+			flatFactors[k] = v
+		}
+	}
+
+	return &models.ScoringResult{
+		PredictIncome:   mlResponse.Prediction,
+		Recommendations: []string{},
+		Factors:         flatFactors,
+	}, nil
+}
+
+func (c *localMLClient) PredictWithExplanation(ctx context.Context, features map[string]interface{}) (*dto.MLScoringResponse, error) {
+	if len(features) == 0 {
+		return nil, fmt.Errorf("features cannot be empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	x := c.model.vectorize(features)
+	prediction := c.model.predict(x)
+	phi := c.model.contributions(x)
+
+	positive := make(map[string]float64)
+	negative := make(map[string]float64)
+	for i, value := range phi {
+		switch {
+		case value > 0:
+			positive[c.model.featureNames[i]] = value
+		case value < 0:
+			negative[c.model.featureNames[i]] = value
+		}
+	}
+
+	response := &dto.MLScoringResponse{
+		Prediction: prediction,
+		Explanation: map[string]map[string]float64{
+			"positive": positive,
+			"negative": negative,
+		},
+		ID:              uuid.New().String(),
+		ModelVersion:    c.modelVersion,
+		PipelineVersion: c.pipelineVersion,
+	}
+
+	c.logger.Debug("Local prediction completed", "prediction", prediction, "uid", response.ID)
+	return response, nil
+}
+
+func (c *localMLClient) SendTrainingData(ctx context.Context, data interface{}) error {
+	return fmt.Errorf("training is not supported by the local model")
+}
+
+func (c *localMLClient) HealthCheck(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,185 @@
+package ml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+)
+
+// RetryPolicy настройки повторов запросов к ML-сервису.
+// MaxRetries — число повторов сверх первой попытки.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// backoff возвращает задержку перед повтором attempt (с 1) — экспонента с full jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
+
+// transientError ошибка, после которой имеет смысл повторить запрос
+type transientError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+
+func (e *transientError) Unwrap() error { return e.err }
+
+// doWithRetry выполняет запрос с повторами и учетом circuit breaker.
+// Тело успешного ответа (2xx) должен закрыть вызывающий.
+func (c *mlClient) doWithRetry(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.retry.backoff(attempt)
+			var transient *transientError
+			if errors.As(lastErr, &transient) && transient.retryAfter > delay {
+				delay = transient.retryAfter
+				if c.retry.MaxDelay > 0 && delay > c.retry.MaxDelay {
+					delay = c.retry.MaxDelay
+				}
+			}
+
+			c.logger.Warn("Retrying ML request", "url", url, "attempt", attempt, "delay_ms", delay.Milliseconds(), "error", lastErr)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if ok, retryAfter := c.breaker.allow(); !ok {
+			c.logger.Warn("ML circuit breaker is open, failing fast", "url", url, "retry_after", retryAfter)
+			return nil, &domainerrors.RetryAfterError{
+				Err:        domainerrors.ErrMLServiceUnavailable,
+				RetryAfter: retryAfter,
+			}
+		}
+
+		resp, err := c.doOnce(ctx, method, url, body)
+		if err == nil {
+			c.breaker.recordSuccess()
+			return resp, nil
+		}
+
+		if ctx.Err() != nil {
+			// Запрос отменил вызывающий или истек его таймаут — о состоянии ML
+			// это ничего не говорит, breaker только отпускает пробный запрос
+			c.breaker.releaseProbe()
+			return nil, ctx.Err()
+		}
+
+		var transient *transientError
+		if !errors.As(err, &transient) {
+			// Ответ получен, сервис жив — это ошибка запроса, а не недоступность
+			c.breaker.recordSuccess()
+			return nil, err
+		}
+
+		if c.breaker.recordFailure() {
+			c.logger.Error("ML circuit breaker opened", "url", url, "open_timeout", c.breakerPolicy.OpenTimeout)
+		}
+		lastErr = err
+	}
+
+	c.logger.Error("ML request failed after retries", "url", url, "attempts", c.retry.MaxRetries+1, "error", lastErr)
+	return nil, &domainerrors.RetryAfterError{
+		Err:        fmt.Errorf("%w: %w", domainerrors.ErrMLServiceUnavailable, lastErr),
+		RetryAfter: c.breakerPolicy.OpenTimeout,
+	}
+}
+
+func (c *mlClient) doOnce(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if isTransientNetworkError(err) {
+			return nil, &transientError{err: fmt.Errorf("failed to execute request: %w", err)}
+		}
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	statusErr := fmt.Errorf("ML service returned status %d: %s", resp.StatusCode, string(respBody))
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &transientError{err: statusErr, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return nil, statusErr
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package ml
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces/mocks"
+)
+
+// fakeClock ручные часы для circuit breaker
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newHalfOpenClient клиент с разомкнутым breaker, у которого истек OpenTimeout
+func newHalfOpenClient(t *testing.T, handler http.HandlerFunc) *mlClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	policy := BreakerPolicy{FailureThreshold: 1, OpenTimeout: 30 * time.Second}
+	client := NewMLClient(server.URL, 5, "v1", "p1", RetryPolicy{}, policy, &mocks.MockLogger{}).(*mlClient)
+	client.breaker.now = clock.Now
+
+	if !client.breaker.recordFailure() {
+		t.Fatal("breaker should open after the first failure")
+	}
+	clock.Advance(policy.OpenTimeout)
+	return client
+}
+
+func TestDoWithRetryTimedOutProbeReleasesBreaker(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	var healthy atomic.Bool
+	client := newHalfOpenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.doWithRetry(ctx, http.MethodGet, client.baseURL, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if state := client.breaker.currentState(); state != breakerHalfOpen {
+		t.Fatalf("expected half-open breaker after timed-out probe, got %s", state)
+	}
+
+	healthy.Store(true)
+	resp, err := client.doWithRetry(context.Background(), http.MethodGet, client.baseURL, nil)
+	if err != nil {
+		t.Fatalf("next probe should be let through, got %v", err)
+	}
+	resp.Body.Close()
+	if state := client.breaker.currentState(); state != breakerClosed {
+		t.Fatalf("expected closed breaker after successful probe, got %s", state)
+	}
+}
+
+func TestDoWithRetryCanceledProbeDoesNotCloseBreaker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newHalfOpenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	})
+
+	if _, err := client.doWithRetry(ctx, http.MethodGet, client.baseURL, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	if state := client.breaker.currentState(); state != breakerHalfOpen {
+		t.Fatalf("canceled probe must not close the breaker, got %s", state)
+	}
+	if ok, _ := client.breaker.allow(); !ok {
+		t.Fatal("breaker should let the next probe through")
+	}
+}
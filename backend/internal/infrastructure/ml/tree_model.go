@@ -0,0 +1,671 @@
+package ml
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type missingType int
+
+const (
+	// missingNaN — в default-ветку уходят только NaN (XGBoost, LightGBM "NaN")
+	missingNaN missingType = iota
+	// missingZero — NaN и 0 считаются пропуском (LightGBM "Zero")
+	missingZero
+	// missingNone — NaN превращается в 0 и сравнивается с порогом (LightGBM "None")
+	missingNone
+)
+
+type treeNode struct {
+	leaf      bool
+	leafValue float64
+	cover     float64
+
+	feature     int
+	threshold   float64
+	strictLess  bool
+	categories  map[int]bool
+	missing     missingType
+	defaultLeft bool
+
+	left  *treeNode
+	right *treeNode
+}
+
+// goesLeft определяет ветку для значения признака x (NaN — пропуск)
+func (n *treeNode) goesLeft(x float64) bool {
+	if n.categories != nil {
+		// как в LightGBM: пропуск с типом NaN уходит вправо, иначе считается категорией 0
+		if math.IsNaN(x) {
+			if n.missing == missingNaN {
+				return false
+			}
+			x = 0
+		}
+		if x < 0 || x != math.Trunc(x) {
+			return false
+		}
+		return n.categories[int(x)]
+	}
+
+	if n.missing == missingNone && math.IsNaN(x) {
+		x = 0
+	}
+	if math.IsNaN(x) || (n.missing == missingZero && x == 0) {
+		return n.defaultLeft
+	}
+
+	if n.strictLess {
+		return x < n.threshold
+	}
+	return x <= n.threshold
+}
+
+// treeEnsemble модель-ансамбль деревьев (градиентный бустинг) с аддитивным выходом
+type treeEnsemble struct {
+	format       string
+	version      string
+	baseScore    float64
+	featureNames []string
+	featureIndex map[string]int
+	trees        []*treeNode
+	// categories коды категорий по индексу признака: позиция значения в списке
+	// категорий, как pandas category codes в ml-service
+	categories map[int]map[string]int
+}
+
+// unknownCategory значение, которым ml-service заполняет пропуски категориальных признаков
+const unknownCategory = "unknown"
+
+// loadTreeEnsemble читает JSON-дамп модели. Поддерживаются:
+//   - LightGBM: результат booster.dump_model() (объект с tree_info);
+//   - XGBoost: результат booster.get_dump(dump_format="json", with_stats=True) — массив деревьев;
+//   - XGBoost в обертке: {"base_score": 0.5, "feature_names": [...], "trees": [...]}.
+//
+// Категориальные сплиты сравнивают коды категорий, поэтому для их признаков
+// в объекте модели нужен словарь "categories": {"признак": ["кат0", "кат1", ...]}.
+// Модель с категориальными сплитами без словаря не загружается.
+func loadTreeEnsemble(path string, baseScore float64) (*treeEnsemble, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model file: %w", err)
+	}
+	return parseTreeEnsemble(data, baseScore)
+}
+
+func parseTreeEnsemble(data []byte, baseScore float64) (*treeEnsemble, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return nil, fmt.Errorf("model file is empty")
+	}
+
+	var model *treeEnsemble
+	var err error
+
+	if trimmed[0] == '[' {
+		var trees []xgbNode
+		if err := json.Unmarshal(data, &trees); err != nil {
+			return nil, fmt.Errorf("failed to decode XGBoost dump: %w", err)
+		}
+		model, err = buildXGBoost(trees, nil, baseScore)
+	} else {
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, fmt.Errorf("failed to decode model: %w", err)
+		}
+
+		switch {
+		case probe["tree_info"] != nil:
+			var dump lgbModel
+			if err := json.Unmarshal(data, &dump); err != nil {
+				return nil, fmt.Errorf("failed to decode LightGBM dump: %w", err)
+			}
+			model, err = buildLightGBM(&dump)
+			if model != nil {
+				err = model.setCategories(dump.Categories)
+			}
+		case probe["trees"] != nil:
+			var wrapper xgbWrapper
+			if err := json.Unmarshal(data, &wrapper); err != nil {
+				return nil, fmt.Errorf("failed to decode XGBoost model: %w", err)
+			}
+			if wrapper.BaseScore != nil {
+				baseScore = *wrapper.BaseScore
+			}
+			model, err = buildXGBoost(wrapper.Trees, wrapper.FeatureNames, baseScore)
+			if model != nil {
+				model.version = wrapper.Version
+				err = model.setCategories(wrapper.Categories)
+			}
+		default:
+			return nil, fmt.Errorf("unknown model format: expected LightGBM dump_model or XGBoost JSON dump")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(model.trees) == 0 {
+		return nil, fmt.Errorf("model contains no trees")
+	}
+
+	for _, tree := range model.trees {
+		if err := model.checkCategorical(tree); err != nil {
+			return nil, err
+		}
+		ensureCovers(tree)
+	}
+
+	return model, nil
+}
+
+// featureID возвращает индекс признака, добавляя его при первом упоминании
+func (m *treeEnsemble) featureID(name string) int {
+	if idx, ok := m.featureIndex[name]; ok {
+		return idx
+	}
+	idx := len(m.featureNames)
+	m.featureNames = append(m.featureNames, name)
+	m.featureIndex[name] = idx
+	return idx
+}
+
+// setCategories строит коды категорий для признаков модели
+func (m *treeEnsemble) setCategories(categories map[string][]string) error {
+	for name, values := range categories {
+		idx, ok := m.featureIndex[name]
+		if !ok {
+			continue
+		}
+		codes := make(map[string]int, len(values))
+		for code, value := range values {
+			if _, ok := codes[value]; ok {
+				return fmt.Errorf("feature %s: duplicate category %q", name, value)
+			}
+			codes[value] = code
+		}
+		if m.categories == nil {
+			m.categories = make(map[int]map[string]int)
+		}
+		m.categories[idx] = codes
+	}
+	return nil
+}
+
+// checkCategorical проверяет, что для каждого категориального сплита известны коды категорий
+func (m *treeEnsemble) checkCategorical(n *treeNode) error {
+	if n.leaf {
+		return nil
+	}
+	if n.categories != nil && m.categories[n.feature] == nil {
+		return fmt.Errorf("feature %s has categorical splits but no categories in the model file", m.featureNames[n.feature])
+	}
+	if err := m.checkCategorical(n.left); err != nil {
+		return err
+	}
+	return m.checkCategorical(n.right)
+}
+
+// vectorize переводит map признаков в вектор по индексам модели.
+// Отсутствующие и нечисловые значения становятся NaN (пропуск).
+// Категориальные признаки кодируются как в ml-service: пропуск
+// становится "unknown", незнакомая категория - пропуском.
+func (m *treeEnsemble) vectorize(features map[string]interface{}) []float64 {
+	x := make([]float64, len(m.featureNames))
+	for i, name := range m.featureNames {
+		if codes, ok := m.categories[i]; ok {
+			x[i] = encodeCategory(codes, features[name])
+			continue
+		}
+		x[i] = toFloat(features[name])
+	}
+	return x
+}
+
+func encodeCategory(codes map[string]int, value interface{}) float64 {
+	var category string
+	switch v := value.(type) {
+	case nil:
+		category = unknownCategory
+	case string:
+		category = v
+	default:
+		category = fmt.Sprint(v)
+	}
+	if code, ok := codes[category]; ok {
+		return float64(code)
+	}
+	return math.NaN()
+}
+
+func (m *treeEnsemble) predict(x []float64) float64 {
+	sum := m.baseScore
+	for _, tree := range m.trees {
+		node := tree
+		for !node.leaf {
+			if node.goesLeft(x[node.feature]) {
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+		sum += node.leafValue
+	}
+	return sum
+}
+
+// contributions считает SHAP-вклады признаков (path-dependent TreeSHAP,
+// Lundberg et al., 2018). Сумма вкладов равна predict(x) - expectedValue().
+func (m *treeEnsemble) contributions(x []float64) []float64 {
+	phi := make([]float64, len(m.featureNames))
+	for _, tree := range m.trees {
+		treeSHAP(tree, x, phi, nil, 0, 1, 1, -1)
+	}
+	return phi
+}
+
+// expectedValue средний выход модели с учетом покрытия листьев
+func (m *treeEnsemble) expectedValue() float64 {
+	sum := m.baseScore
+	for _, tree := range m.trees {
+		sum += nodeExpectation(tree)
+	}
+	return sum
+}
+
+func nodeExpectation(n *treeNode) float64 {
+	if n.leaf {
+		return n.leafValue
+	}
+	return (n.left.cover*nodeExpectation(n.left) + n.right.cover*nodeExpectation(n.right)) / n.cover
+}
+
+type pathElement struct {
+	feature      int
+	zeroFraction float64
+	oneFraction  float64
+	weight       float64
+}
+
+func treeSHAP(node *treeNode, x, phi []float64, parentPath []pathElement, depth int, parentZero, parentOne float64, parentFeature int) {
+	path := make([]pathElement, depth+1)
+	copy(path, parentPath)
+	extendPath(path, depth, parentZero, parentOne, parentFeature)
+
+	if node.leaf {
+		for i := 1; i <= depth; i++ {
+			w := unwoundPathSum(path, depth, i)
+			el := path[i]
+			phi[el.feature] += w * (el.oneFraction - el.zeroFraction) * node.leafValue
+		}
+		return
+	}
+
+	hot, cold := node.right, node.left
+	if node.goesLeft(x[node.feature]) {
+		hot, cold = node.left, node.right
+	}
+
+	incomingZero, incomingOne := 1.0, 1.0
+	pathIndex := 0
+	for ; pathIndex <= depth; pathIndex++ {
+		if path[pathIndex].feature == node.feature {
+			break
+		}
+	}
+	if pathIndex != depth+1 {
+		incomingZero = path[pathIndex].zeroFraction
+		incomingOne = path[pathIndex].oneFraction
+		unwindPath(path, depth, pathIndex)
+		depth--
+		path = path[:depth+1]
+	}
+
+	treeSHAP(hot, x, phi, path, depth+1, hot.cover/node.cover*incomingZero, incomingOne, node.feature)
+	treeSHAP(cold, x, phi, path, depth+1, cold.cover/node.cover*incomingZero, 0, node.feature)
+}
+
+func extendPath(path []pathElement, depth int, zeroFraction, oneFraction float64, feature int) {
+	weight := 0.0
+	if depth == 0 {
+		weight = 1
+	}
+	path[depth] = pathElement{feature: feature, zeroFraction: zeroFraction, oneFraction: oneFraction, weight: weight}
+
+	d := float64(depth + 1)
+	for i := depth - 1; i >= 0; i-- {
+		path[i+1].weight += oneFraction * path[i].weight * float64(i+1) / d
+		path[i].weight = zeroFraction * path[i].weight * float64(depth-i) / d
+	}
+}
+
+func unwindPath(path []pathElement, depth, pathIndex int) {
+	oneFraction := path[pathIndex].oneFraction
+	zeroFraction := path[pathIndex].zeroFraction
+	nextOnePortion := path[depth].weight
+	d := float64(depth + 1)
+
+	for i := depth - 1; i >= 0; i-- {
+		if oneFraction != 0 {
+			tmp := path[i].weight
+			path[i].weight = nextOnePortion * d / (float64(i+1) * oneFraction)
+			nextOnePortion = tmp - path[i].weight*zeroFraction*float64(depth-i)/d
+		} else {
+			path[i].weight = path[i].weight * d / (zeroFraction * float64(depth-i))
+		}
+	}
+
+	for i := pathIndex; i < depth; i++ {
+		path[i].feature = path[i+1].feature
+		path[i].zeroFraction = path[i+1].zeroFraction
+		path[i].oneFraction = path[i+1].oneFraction
+	}
+}
+
+func unwoundPathSum(path []pathElement, depth, pathIndex int) float64 {
+	oneFraction := path[pathIndex].oneFraction
+	zeroFraction := path[pathIndex].zeroFraction
+	nextOnePortion := path[depth].weight
+	total := 0.0
+
+	if oneFraction != 0 {
+		for i := depth - 1; i >= 0; i-- {
+			tmp := nextOnePortion / (float64(i+1) * oneFraction)
+			total += tmp
+			nextOnePortion = path[i].weight - tmp*zeroFraction*float64(depth-i)
+		}
+	} else if zeroFraction != 0 {
+		for i := depth - 1; i >= 0; i-- {
+			total += path[i].weight / (float64(depth-i) * zeroFraction)
+		}
+	}
+
+	return total * float64(depth+1)
+}
+
+// ensureCovers проставляет покрытие узлов. Если в дампе нет статистики,
+// используется число листьев — то есть листья считаются равновероятными.
+func ensureCovers(root *treeNode) {
+	if hasCovers(root) {
+		return
+	}
+	var countLeaves func(n *treeNode) float64
+	countLeaves = func(n *treeNode) float64 {
+		if n.leaf {
+			n.cover = 1
+		} else {
+			n.cover = countLeaves(n.left) + countLeaves(n.right)
+		}
+		return n.cover
+	}
+	countLeaves(root)
+}
+
+func hasCovers(n *treeNode) bool {
+	if n.cover <= 0 {
+		return false
+	}
+	if n.leaf {
+		return true
+	}
+	return hasCovers(n.left) && hasCovers(n.right)
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	case string:
+		if f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64); err == nil {
+			return f
+		}
+	}
+	return math.NaN()
+}
+
+// --- XGBoost ---
+
+type xgbNode struct {
+	NodeID         int       `json:"nodeid"`
+	Split          string    `json:"split"`
+	SplitCondition float64   `json:"split_condition"`
+	Yes            int       `json:"yes"`
+	No             int       `json:"no"`
+	Missing        int       `json:"missing"`
+	Leaf           *float64  `json:"leaf"`
+	Cover          float64   `json:"cover"`
+	Categories     []int     `json:"categories"`
+	Children       []xgbNode `json:"children"`
+}
+
+type xgbWrapper struct {
+	Version      string              `json:"version"`
+	BaseScore    *float64            `json:"base_score"`
+	FeatureNames []string            `json:"feature_names"`
+	Trees        []xgbNode           `json:"trees"`
+	Categories   map[string][]string `json:"categories"`
+}
+
+func buildXGBoost(trees []xgbNode, featureNames []string, baseScore float64) (*treeEnsemble, error) {
+	model := &treeEnsemble{
+		format:       "xgboost",
+		baseScore:    baseScore,
+		featureIndex: make(map[string]int),
+	}
+
+	for i := range trees {
+		root, err := model.convertXGBNode(&trees[i], featureNames)
+		if err != nil {
+			return nil, fmt.Errorf("tree %d: %w", i, err)
+		}
+		model.trees = append(model.trees, root)
+	}
+
+	return model, nil
+}
+
+func (m *treeEnsemble) convertXGBNode(src *xgbNode, featureNames []string) (*treeNode, error) {
+	if src.Leaf != nil {
+		return &treeNode{leaf: true, leafValue: *src.Leaf, cover: src.Cover}, nil
+	}
+	if src.Categories != nil {
+		return nil, fmt.Errorf("node %d: categorical splits are not supported for XGBoost", src.NodeID)
+	}
+	if len(src.Children) != 2 {
+		return nil, fmt.Errorf("node %d: expected 2 children, got %d", src.NodeID, len(src.Children))
+	}
+
+	children := make(map[int]*xgbNode, 2)
+	for i := range src.Children {
+		children[src.Children[i].NodeID] = &src.Children[i]
+	}
+	yes, no := children[src.Yes], children[src.No]
+	if yes == nil || no == nil {
+		return nil, fmt.Errorf("node %d: children do not match yes/no ids", src.NodeID)
+	}
+
+	left, err := m.convertXGBNode(yes, featureNames)
+	if err != nil {
+		return nil, err
+	}
+	right, err := m.convertXGBNode(no, featureNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &treeNode{
+		cover:       src.Cover,
+		feature:     m.featureID(resolveXGBFeature(src.Split, featureNames)),
+		threshold:   src.SplitCondition,
+		strictLess:  true,
+		missing:     missingNaN,
+		defaultLeft: src.Missing == src.Yes,
+		left:        left,
+		right:       right,
+	}, nil
+}
+
+// resolveXGBFeature переводит имена вида f12 в настоящие имена признаков
+func resolveXGBFeature(split string, featureNames []string) string {
+	if len(featureNames) > 0 && strings.HasPrefix(split, "f") {
+		if idx, err := strconv.Atoi(split[1:]); err == nil && idx >= 0 && idx < len(featureNames) {
+			return featureNames[idx]
+		}
+	}
+	return split
+}
+
+// --- LightGBM ---
+
+type lgbModel struct {
+	Version      string              `json:"version"`
+	FeatureNames []string            `json:"feature_names"`
+	TreeInfo     []lgbTree           `json:"tree_info"`
+	Categories   map[string][]string `json:"categories"`
+}
+
+type lgbTree struct {
+	TreeIndex int     `json:"tree_index"`
+	Shrinkage float64 `json:"shrinkage"`
+	Structure lgbNode `json:"tree_structure"`
+}
+
+type lgbNode struct {
+	SplitFeature  *int            `json:"split_feature"`
+	Threshold     json.RawMessage `json:"threshold"`
+	DecisionType  string          `json:"decision_type"`
+	DefaultLeft   bool            `json:"default_left"`
+	MissingType   string          `json:"missing_type"`
+	InternalCount float64         `json:"internal_count"`
+	LeftChild     *lgbNode        `json:"left_child"`
+	RightChild    *lgbNode        `json:"right_child"`
+	LeafValue     *float64        `json:"leaf_value"`
+	LeafCount     float64         `json:"leaf_count"`
+}
+
+func buildLightGBM(dump *lgbModel) (*treeEnsemble, error) {
+	model := &treeEnsemble{
+		format:       "lightgbm",
+		featureIndex: make(map[string]int),
+	}
+	for _, name := range dump.FeatureNames {
+		model.featureID(name)
+	}
+
+	for _, tree := range dump.TreeInfo {
+		root, err := model.convertLGBNode(&tree.Structure, dump.FeatureNames)
+		if err != nil {
+			return nil, fmt.Errorf("tree %d: %w", tree.TreeIndex, err)
+		}
+		model.trees = append(model.trees, root)
+	}
+
+	return model, nil
+}
+
+func (m *treeEnsemble) convertLGBNode(src *lgbNode, featureNames []string) (*treeNode, error) {
+	if src.SplitFeature == nil {
+		if src.LeafValue == nil {
+			return nil, fmt.Errorf("leaf without leaf_value")
+		}
+		return &treeNode{leaf: true, leafValue: *src.LeafValue, cover: src.LeafCount}, nil
+	}
+	if src.LeftChild == nil || src.RightChild == nil {
+		return nil, fmt.Errorf("split node without children")
+	}
+	if *src.SplitFeature < 0 || *src.SplitFeature >= len(featureNames) {
+		return nil, fmt.Errorf("split_feature %d out of range", *src.SplitFeature)
+	}
+
+	left, err := m.convertLGBNode(src.LeftChild, featureNames)
+	if err != nil {
+		return nil, err
+	}
+	right, err := m.convertLGBNode(src.RightChild, featureNames)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &treeNode{
+		cover:       src.InternalCount,
+		feature:     m.featureID(featureNames[*src.SplitFeature]),
+		defaultLeft: src.DefaultLeft,
+		left:        left,
+		right:       right,
+	}
+
+	switch src.MissingType {
+	case "Zero":
+		node.missing = missingZero
+	case "NaN":
+		node.missing = missingNaN
+	default:
+		node.missing = missingNone
+	}
+
+	switch src.DecisionType {
+	case "==":
+		node.categories, err = parseLGBCategories(src.Threshold)
+	case "<=", "":
+		node.threshold, err = parseLGBThreshold(src.Threshold)
+	default:
+		err = fmt.Errorf("unsupported decision_type %q", src.DecisionType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func parseLGBThreshold(raw json.RawMessage) (float64, error) {
+	var threshold float64
+	if err := json.Unmarshal(raw, &threshold); err == nil {
+		return threshold, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, fmt.Errorf("invalid threshold %s", string(raw))
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseLGBCategories разбирает порог категориального сплита вида "1||3||7"
+func parseLGBCategories(raw json.RawMessage) (map[int]bool, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		var single float64
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, fmt.Errorf("invalid categorical threshold %s", string(raw))
+		}
+		return map[int]bool{int(single): true}, nil
+	}
+
+	categories := make(map[int]bool)
+	for _, part := range strings.Split(s, "||") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid category %q: %w", part, err)
+		}
+		categories[value] = true
+	}
+	return categories, nil
+}
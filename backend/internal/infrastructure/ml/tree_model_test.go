@@ -0,0 +1,201 @@
+package ml
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// lightGBMDump три дерева: числовой сплит с пропуском NaN и default-left,
+// сплит с пропуском Zero, категориальный сплит и сплит с пропуском None
+const lightGBMDump = `{
+	"version": "v4",
+	"feature_names": ["a", "b", "c"],
+	"categories": {"c": ["x", "y", "z", "unknown"]},
+	"tree_info": [
+		{"tree_index": 0, "tree_structure": {
+			"split_feature": 0, "threshold": 1.5, "decision_type": "<=",
+			"default_left": true, "missing_type": "NaN", "internal_count": 100,
+			"left_child": {"leaf_value": 1, "leaf_count": 60},
+			"right_child": {
+				"split_feature": 1, "threshold": 0, "decision_type": "<=",
+				"default_left": false, "missing_type": "Zero", "internal_count": 40,
+				"left_child": {"leaf_value": 2, "leaf_count": 10},
+				"right_child": {"leaf_value": 3, "leaf_count": 30}
+			}
+		}},
+		{"tree_index": 1, "tree_structure": {
+			"split_feature": 2, "threshold": "1||2", "decision_type": "==",
+			"default_left": false, "missing_type": "NaN", "internal_count": 100,
+			"left_child": {"leaf_value": 0.5, "leaf_count": 50},
+			"right_child": {"leaf_value": -0.5, "leaf_count": 50}
+		}},
+		{"tree_index": 2, "tree_structure": {
+			"split_feature": 0, "threshold": 0.5, "decision_type": "<=",
+			"default_left": false, "missing_type": "None", "internal_count": 100,
+			"left_child": {"leaf_value": 10, "leaf_count": 30},
+			"right_child": {"leaf_value": 20, "leaf_count": 70}
+		}}
+	]
+}`
+
+// xgboostDump одно дерево в обертке с именами признаков и base_score
+const xgboostDump = `{
+	"base_score": 0.5,
+	"feature_names": ["a", "b"],
+	"trees": [
+		{"nodeid": 0, "split": "f0", "split_condition": 1.5, "yes": 1, "no": 2, "missing": 2, "cover": 100, "children": [
+			{"nodeid": 1, "leaf": 0.3, "cover": 40},
+			{"nodeid": 2, "split": "f1", "split_condition": 0, "yes": 3, "no": 4, "missing": 3, "cover": 60, "children": [
+				{"nodeid": 3, "leaf": -0.2, "cover": 20},
+				{"nodeid": 4, "leaf": 0.1, "cover": 40}
+			]}
+		]}
+	]
+}`
+
+func TestTreeEnsemblePredict(t *testing.T) {
+	tests := []struct {
+		name         string
+		dump         string
+		features     map[string]interface{}
+		want         float64
+		wantExpected float64
+	}{
+		{
+			name:         "lightgbm numeric and known category",
+			dump:         lightGBMDump,
+			features:     map[string]interface{}{"a": 1.0, "b": 5.0, "c": "y"},
+			want:         1 + 0.5 + 20,
+			wantExpected: 18.7,
+		},
+		{
+			name:         "lightgbm missing goes default-left, missing category is unknown, None missing is zero",
+			dump:         lightGBMDump,
+			features:     map[string]interface{}{"b": 5.0},
+			want:         1 - 0.5 + 10,
+			wantExpected: 18.7,
+		},
+		{
+			name:         "lightgbm zero is missing for Zero split",
+			dump:         lightGBMDump,
+			features:     map[string]interface{}{"a": 2.0, "b": 0.0, "c": "x"},
+			want:         3 - 0.5 + 20,
+			wantExpected: 18.7,
+		},
+		{
+			name:         "lightgbm unseen category goes right",
+			dump:         lightGBMDump,
+			features:     map[string]interface{}{"a": "2", "b": -1.0, "c": "new"},
+			want:         2 - 0.5 + 20,
+			wantExpected: 18.7,
+		},
+		{
+			name:         "xgboost strict less than",
+			dump:         xgboostDump,
+			features:     map[string]interface{}{"a": 1.5, "b": -1.0},
+			want:         0.5 - 0.2,
+			wantExpected: 0.62,
+		},
+		{
+			name:         "xgboost left branch",
+			dump:         xgboostDump,
+			features:     map[string]interface{}{"a": 1.0},
+			want:         0.5 + 0.3,
+			wantExpected: 0.62,
+		},
+		{
+			name:         "xgboost missing follows missing id",
+			dump:         xgboostDump,
+			features:     map[string]interface{}{"b": 0.0},
+			want:         0.5 + 0.1,
+			wantExpected: 0.62,
+		},
+		{
+			name:         "xgboost missing on both splits",
+			dump:         xgboostDump,
+			features:     map[string]interface{}{},
+			want:         0.5 - 0.2,
+			wantExpected: 0.62,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := parseTreeEnsemble([]byte(tt.dump), 0)
+			if err != nil {
+				t.Fatalf("parseTreeEnsemble: %v", err)
+			}
+
+			x := model.vectorize(tt.features)
+			got := model.predict(x)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("predict = %v, want %v", got, tt.want)
+			}
+
+			expected := model.expectedValue()
+			if math.Abs(expected-tt.wantExpected) > 1e-9 {
+				t.Errorf("expectedValue = %v, want %v", expected, tt.wantExpected)
+			}
+
+			sum := 0.0
+			for _, phi := range model.contributions(x) {
+				sum += phi
+			}
+			if math.Abs(sum-(got-expected)) > 1e-9 {
+				t.Errorf("sum of contributions = %v, want prediction - expected value = %v", sum, got-expected)
+			}
+		})
+	}
+}
+
+func TestTreeEnsembleSingleFeatureContribution(t *testing.T) {
+	model, err := parseTreeEnsemble([]byte(lightGBMDump), 0)
+	if err != nil {
+		t.Fatalf("parseTreeEnsemble: %v", err)
+	}
+
+	// c используется только в дереве со средним 0, поэтому его вклад равен листу
+	phi := model.contributions(model.vectorize(map[string]interface{}{"a": 1.0, "c": "z"}))
+	if got := phi[model.featureIndex["c"]]; math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("contribution of c = %v, want 0.5", got)
+	}
+}
+
+func TestParseTreeEnsembleRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		dump    string
+		wantErr string
+	}{
+		{
+			name:    "categorical split without categories",
+			dump:    strings.Replace(lightGBMDump, `"categories": {"c": ["x", "y", "z", "unknown"]},`, "", 1),
+			wantErr: "feature c has categorical splits",
+		},
+		{
+			name:    "duplicate category",
+			dump:    strings.Replace(lightGBMDump, `["x", "y", "z", "unknown"]`, `["x", "x"]`, 1),
+			wantErr: `duplicate category "x"`,
+		},
+		{
+			name:    "xgboost categorical split",
+			dump:    `[{"nodeid": 0, "split": "f0", "categories": [1], "yes": 1, "no": 2, "missing": 2, "children": [{"nodeid": 1, "leaf": 1}, {"nodeid": 2, "leaf": 2}]}]`,
+			wantErr: "categorical splits are not supported",
+		},
+		{
+			name:    "empty model",
+			dump:    " ",
+			wantErr: "model file is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTreeEnsemble([]byte(tt.dump), 0)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
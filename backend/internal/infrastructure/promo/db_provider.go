@@ -0,0 +1,208 @@
+package promo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/dto"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+)
+
+// dbPromoProvider подбирает промо по каталогу и кампаниям из базы. Каталог кешируется
+// на cacheTTL, чтобы пакетный скоринг не ходил в базу за каждым клиентом.
+// Лимиты выдач кампаний проверяются в базе при каждой выдаче, один клиент
+// занимает в лимите одно место.
+type dbPromoProvider struct {
+	repo     interfaces.PromoRepository
+	cacheTTL time.Duration
+	logger   interfaces.Logger
+
+	mu       sync.Mutex
+	cached   *catalog
+	loadedAt time.Time
+}
+
+// catalog снимок категорий и кампаний, сгруппированных по категориям
+type catalog struct {
+	categories []models.PromoCategory
+	campaigns  map[int64][]campaignEntry
+}
+
+type campaignEntry struct {
+	campaign models.PromoCampaign
+	rules    []models.TargetingRule
+}
+
+func NewDBPromoProvider(repo interfaces.PromoRepository, cacheTTL time.Duration, logger interfaces.Logger) interfaces.PromoProvider {
+	return &dbPromoProvider{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		logger:   logger.With("component", "DBPromoProvider"),
+	}
+}
+
+func (p *dbPromoProvider) GetRecommendations(ctx context.Context, req *dto.PromoRequest) ([]dto.Recommendation, error) {
+	now := req.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	current, err := p.catalog(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	values := req.Variables()
+	income := int64(req.PredictIncome)
+
+	// предложения кампаний идут раньше предложений по доходу, внутри - в порядке категорий
+	var campaignOffers, bandOffers []dto.Recommendation
+	for i := range current.categories {
+		category := &current.categories[i]
+		if entry, ok := p.pickCampaign(ctx, current.campaigns[category.ID], req.ClientID, values, now, req.Preview); ok {
+			campaignOffers = append(campaignOffers, campaignRecommendation(category, entry))
+			continue
+		}
+		for j := range category.Bands {
+			if category.Bands[j].Matches(income) {
+				bandOffers = append(bandOffers, bandRecommendation(category, &category.Bands[j]))
+				break
+			}
+		}
+	}
+
+	result := append(campaignOffers, bandOffers...)
+	if result == nil {
+		result = []dto.Recommendation{}
+	}
+	for i := range result {
+		result[i].Priority = i + 1
+	}
+	return result, nil
+}
+
+func campaignRecommendation(category *models.PromoCategory, entry *campaignEntry) dto.Recommendation {
+	reason := fmt.Sprintf("Клиент подходит под кампанию «%s»", entry.campaign.Name)
+	if len(entry.rules) > 0 {
+		conditions := make([]string, 0, len(entry.rules))
+		for _, rule := range entry.rules {
+			conditions = append(conditions, rule.String())
+		}
+		reason += ": " + strings.Join(conditions, "; ")
+	}
+
+	return dto.Recommendation{
+		ID:                fmt.Sprintf("campaign-%d", entry.campaign.ID),
+		Category:          category.Kind,
+		Title:             entry.campaign.Promo,
+		Description:       entry.campaign.Description,
+		EligibilityReason: reason,
+		CTAURL:            entry.campaign.CTAURL,
+	}
+}
+
+func bandRecommendation(category *models.PromoCategory, band *models.PromoBand) dto.Recommendation {
+	return dto.Recommendation{
+		ID:                fmt.Sprintf("band-%d", band.ID),
+		Category:          category.Kind,
+		Title:             band.Promo,
+		Description:       band.Description,
+		EligibilityReason: fmt.Sprintf("Прогноз дохода от %d до %d ₽", band.MinIncome, band.MaxIncome),
+		CTAURL:            band.CTAURL,
+	}
+}
+
+// pickCampaign возвращает активную кампанию с наибольшим приоритетом, под условия
+// которой подходит клиент и у которой не исчерпан лимит выдач. Лимит по снимку
+// каталога не проверяется: клиент, которому кампания уже выдана, получает ее снова.
+func (p *dbPromoProvider) pickCampaign(ctx context.Context, entries []campaignEntry, clientID int64, values map[string]interface{}, now time.Time, preview bool) (*campaignEntry, bool) {
+	for i := range entries {
+		entry := &entries[i]
+		if !entry.campaign.IsActive(now) || !matchesAll(entry.rules, values) {
+			continue
+		}
+		if preview && !entry.campaign.HasCapacity() {
+			continue
+		}
+
+		if preview {
+			return entry, true
+		}
+
+		reserved, err := p.repo.ReserveCampaign(ctx, entry.campaign.ID, clientID)
+		if err != nil {
+			p.logger.Error("Failed to reserve promo campaign", "campaign_id", entry.campaign.ID, "client_id", clientID, "error", err)
+			continue
+		}
+		if !reserved {
+			p.logger.Debug("Promo campaign cap reached", "campaign_id", entry.campaign.ID)
+			continue
+		}
+		return entry, true
+	}
+	return nil, false
+}
+
+func matchesAll(rules []models.TargetingRule, values map[string]interface{}) bool {
+	for i := range rules {
+		if !rules[i].Matches(values) {
+			return false
+		}
+	}
+	return true
+}
+
+// catalog возвращает каталог из кеша или перечитывает его из базы.
+// Если база недоступна, используется последний загруженный каталог.
+func (p *dbPromoProvider) catalog(ctx context.Context, now time.Time) (*catalog, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached != nil && time.Since(p.loadedAt) < p.cacheTTL {
+		return p.cached, nil
+	}
+
+	loaded, err := p.load(ctx, now)
+	if err != nil {
+		if p.cached != nil {
+			p.logger.Warn("Failed to reload promo catalog, using cached", "error", err)
+			return p.cached, nil
+		}
+		return nil, fmt.Errorf("failed to load promo catalog: %w", err)
+	}
+
+	p.cached = loaded
+	p.loadedAt = time.Now()
+	return loaded, nil
+}
+
+func (p *dbPromoProvider) load(ctx context.Context, now time.Time) (*catalog, error) {
+	categories, err := p.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns, err := p.repo.ListLiveCampaigns(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[int64][]campaignEntry)
+	for _, campaign := range campaigns {
+		rules, err := campaign.Rules()
+		if err != nil {
+			p.logger.Warn("Skipping promo campaign with invalid targeting", "campaign_id", campaign.ID, "error", err)
+			continue
+		}
+		grouped[campaign.CategoryID] = append(grouped[campaign.CategoryID], campaignEntry{
+			campaign: campaign,
+			rules:    rules,
+		})
+	}
+
+	return &catalog{categories: categories, campaigns: grouped}, nil
+}
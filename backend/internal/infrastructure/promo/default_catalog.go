@@ -0,0 +1,52 @@
+package promo
+
+import "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+
+// DefaultCatalog начальный каталог промо, которым заполняется пустая база
+func DefaultCatalog() []models.PromoCategory {
+	return []models.PromoCategory{
+		{
+			Code:      "promo_actions",
+			Name:      "Акции",
+			Kind:      models.PromoKindPromo,
+			SortOrder: 1,
+			Bands: []models.PromoBand{
+				{MinIncome: 0, MaxIncome: 30000, Promo: "Дарим 500р за отзыв!"},
+				{MinIncome: 30000, MaxIncome: 60000, Promo: "Бигфест с кэшбэком 50%!"},
+				{MinIncome: 60000, MaxIncome: 120000, Promo: "Пятничный суперкэшбек!"},
+				{MinIncome: 120000, MaxIncome: 250000, Promo: "Дополнительная категория кэшбека!"},
+				{MinIncome: 250000, MaxIncome: 500000, Promo: "Повышенная ставка по накопительному счёту альфа-банка"},
+				{MinIncome: 500000, MaxIncome: 1000000, Promo: "Счёт для бизнеса за 0 рублей!"},
+				{MinIncome: 1000000, MaxIncome: 1000000000, Promo: "Дарим платёжное кольцо"},
+			},
+		},
+		{
+			Code:      "cards",
+			Name:      "Карты",
+			Kind:      models.PromoKindCard,
+			SortOrder: 2,
+			Bands: []models.PromoBand{
+				{MinIncome: 0, MaxIncome: 30000, Promo: "Альфа-Стикер"},
+				{MinIncome: 30000, MaxIncome: 60000, Promo: "Сверхтонкий стикер с котами"},
+				{MinIncome: 60000, MaxIncome: 120000, Promo: "Карта Альфа и Золотое Яблоко"},
+				{MinIncome: 120000, MaxIncome: 250000, Promo: "Карта Альфа и Баста"},
+				{MinIncome: 250000, MaxIncome: 1000000, Promo: "Дебетовая карта Alfa Only Аэрофлот"},
+				{MinIncome: 1000000, MaxIncome: 1000000000, Promo: "Дебетовая карта Alfa Only"},
+			},
+		},
+		{
+			Code:      "investment",
+			Name:      "Инвестиции",
+			Kind:      models.PromoKindInvestment,
+			SortOrder: 3,
+			Bands: []models.PromoBand{
+				{MinIncome: 0, MaxIncome: 30000, Promo: "Платим 5 000 ₽ каждому"},
+				{MinIncome: 30000, MaxIncome: 60000, Promo: "Старт в инвестициях всего со 100 рублей!"},
+				{MinIncome: 60000, MaxIncome: 120000, Promo: "Платим 10 000 ₽ каждому"},
+				{MinIncome: 120000, MaxIncome: 250000, Promo: "Акции в подарок"},
+				{MinIncome: 250000, MaxIncome: 1000000, Promo: "Инвест-копилка"},
+				{MinIncome: 1000000, MaxIncome: 1000000000, Promo: "Тарифный план: Персональный брокер"},
+			},
+		},
+	}
+}
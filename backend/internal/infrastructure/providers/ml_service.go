@@ -1,22 +1,94 @@
 package providers
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/config"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
 	"github.com/Godrik0/HackChange-Alpha/backend/internal/infrastructure/ml"
 )
 
 type MLServiceProvider interface {
-	ProvideMLService(cfg *config.Config, logger interfaces.Logger) interfaces.MLService
+	ProvideMLService(cfg *config.Config, logger interfaces.Logger) (interfaces.MLService, error)
+	// ProvideModelVariants создает клиента ML-сервиса для каждого варианта из ml.routing.variants
+	ProvideModelVariants(cfg *config.Config, logger interfaces.Logger) (map[string]interfaces.MLService, error)
 }
 
 type DefaultMLServiceProvider struct{}
 
-func (p *DefaultMLServiceProvider) ProvideMLService(cfg *config.Config, logger interfaces.Logger) interfaces.MLService {
-	return ml.NewMLClient(
+func (p *DefaultMLServiceProvider) ProvideMLService(cfg *config.Config, logger interfaces.Logger) (interfaces.MLService, error) {
+	switch cfg.ML.Backend {
+	case "local":
+		return provideLocalMLService(cfg, logger)
+	case "http", "":
+	default:
+		return nil, fmt.Errorf("unknown ml.backend %q (expected http or local)", cfg.ML.Backend)
+	}
+
+	return provideHTTPMLService(cfg, cfg.ML.ModelVersion, cfg.ML.PipelineVersion, logger)
+}
+
+func (p *DefaultMLServiceProvider) ProvideModelVariants(cfg *config.Config, logger interfaces.Logger) (map[string]interfaces.MLService, error) {
+	variants := make(map[string]interfaces.MLService, len(cfg.ML.Routing.Variants))
+	if len(cfg.ML.Routing.Variants) == 0 {
+		return variants, nil
+	}
+	if cfg.ML.Backend == "local" {
+		return nil, fmt.Errorf("ml.routing.variants require ml.backend http")
+	}
+
+	for _, variant := range cfg.ML.Routing.Variants {
+		pipelineVersion := variant.PipelineVersion
+		if pipelineVersion == "" {
+			pipelineVersion = cfg.ML.PipelineVersion
+		}
+
+		service, err := provideHTTPMLService(cfg, variant.ModelVersion, pipelineVersion, logger.With("variant", variant.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize variant %s: %w", variant.Name, err)
+		}
+		variants[variant.Name] = service
+	}
+
+	return variants, nil
+}
+
+func provideHTTPMLService(cfg *config.Config, modelVersion, pipelineVersion string, logger interfaces.Logger) (interfaces.MLService, error) {
+	httpClient := ml.NewMLClient(
 		cfg.ML.BaseURL,
 		cfg.ML.Timeout,
-		cfg.ML.ModelVersion,
+		modelVersion,
+		pipelineVersion,
+		ml.RetryPolicy{
+			MaxRetries: cfg.ML.Retry.MaxRetries,
+			BaseDelay:  time.Duration(cfg.ML.Retry.BaseDelayMs) * time.Millisecond,
+			MaxDelay:   time.Duration(cfg.ML.Retry.MaxDelayMs) * time.Millisecond,
+		},
+		ml.BreakerPolicy{
+			FailureThreshold: cfg.ML.Breaker.FailureThreshold,
+			OpenTimeout:      time.Duration(cfg.ML.Breaker.OpenTimeout) * time.Second,
+		},
+		logger,
+	)
+
+	if !cfg.ML.FallbackToLocal {
+		return httpClient, nil
+	}
+
+	localClient, err := provideLocalMLService(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize fallback model: %w", err)
+	}
+
+	return ml.NewFallbackMLClient(httpClient, localClient, logger), nil
+}
+
+func provideLocalMLService(cfg *config.Config, logger interfaces.Logger) (interfaces.MLService, error) {
+	return ml.NewLocalMLClient(
+		cfg.ML.Local.ModelPath,
+		cfg.ML.Local.BaseScore,
+		cfg.ML.Local.ModelVersion,
 		cfg.ML.PipelineVersion,
 		logger,
 	)
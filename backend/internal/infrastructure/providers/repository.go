@@ -8,6 +8,12 @@ import (
 
 type RepositoryProvider interface {
 	ProvideClientRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ClientRepository
+	ProvideScoringRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ScoringRepository
+	ProvidePromoRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoRepository
+	ProvidePromoImpressionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoImpressionRepository
+	ProvideTrainingRepository(db *gorm.DB, logger interfaces.Logger) interfaces.TrainingRepository
+	ProvideDriftRepository(db *gorm.DB, logger interfaces.Logger) interfaces.DriftRepository
+	ProvideShadowPredictionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ShadowPredictionRepository
 }
 
 type DefaultRepositoryProvider struct{}
@@ -15,3 +21,27 @@ type DefaultRepositoryProvider struct{}
 func (p *DefaultRepositoryProvider) ProvideClientRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ClientRepository {
 	return storage.NewClientRepository(db, logger)
 }
+
+func (p *DefaultRepositoryProvider) ProvideScoringRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ScoringRepository {
+	return storage.NewScoringRepository(db, logger)
+}
+
+func (p *DefaultRepositoryProvider) ProvidePromoRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoRepository {
+	return storage.NewPromoRepository(db, logger)
+}
+
+func (p *DefaultRepositoryProvider) ProvidePromoImpressionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoImpressionRepository {
+	return storage.NewPromoImpressionRepository(db, logger)
+}
+
+func (p *DefaultRepositoryProvider) ProvideTrainingRepository(db *gorm.DB, logger interfaces.Logger) interfaces.TrainingRepository {
+	return storage.NewTrainingRepository(db, logger)
+}
+
+func (p *DefaultRepositoryProvider) ProvideDriftRepository(db *gorm.DB, logger interfaces.Logger) interfaces.DriftRepository {
+	return storage.NewDriftRepository(db, logger)
+}
+
+func (p *DefaultRepositoryProvider) ProvideShadowPredictionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ShadowPredictionRepository {
+	return storage.NewShadowPredictionRepository(db, logger)
+}
@@ -83,7 +83,7 @@ func (r *clientRepository) GetByID(ctx context.Context, id int64) (*models.Clien
 	return &client, nil
 }
 
-func (r *clientRepository) Search(ctx context.Context, params dto.SearchParams) ([]models.Client, error) {
+func (r *clientRepository) Search(ctx context.Context, params dto.SearchParams, limit int) ([]models.Client, error) {
 	r.logger.Debug("Searching clients", "params", params)
 
 	var clients []models.Client
@@ -111,6 +111,10 @@ func (r *clientRepository) Search(ctx context.Context, params dto.SearchParams)
 		}
 	}
 
+	if limit > 0 {
+		query = query.Order("id ASC").Limit(limit)
+	}
+
 	result := query.Find(&clients)
 	if result.Error != nil {
 		r.logger.Error("Failed to search clients", "error", result.Error)
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type driftRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewDriftRepository(db *gorm.DB, logger interfaces.Logger) interfaces.DriftRepository {
+	return &driftRepository{
+		db:     db,
+		logger: logger.With("component", "DriftRepository"),
+	}
+}
+
+func (r *driftRepository) ReplaceReferences(ctx context.Context, references []models.DriftReference) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.DriftReference{}).Error; err != nil {
+			return fmt.Errorf("failed to delete drift references: %w", err)
+		}
+		if len(references) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(references, 100).Error; err != nil {
+			return fmt.Errorf("failed to save drift references: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to replace drift references", "error", err)
+		return err
+	}
+
+	r.logger.Info("Drift references replaced", "count", len(references))
+	return nil
+}
+
+func (r *driftRepository) ListReferences(ctx context.Context) ([]models.DriftReference, error) {
+	var references []models.DriftReference
+	result := r.db.WithContext(ctx).Order("name ASC").Find(&references)
+	if result.Error != nil {
+		r.logger.Error("Failed to list drift references", "error", result.Error)
+		return nil, fmt.Errorf("failed to list drift references: %w", result.Error)
+	}
+
+	return references, nil
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type promoImpressionRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewPromoImpressionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoImpressionRepository {
+	return &promoImpressionRepository{
+		db:     db,
+		logger: logger.With("component", "PromoImpressionRepository"),
+	}
+}
+
+func (r *promoImpressionRepository) CreateImpressions(ctx context.Context, impressions []models.PromoImpression) error {
+	if len(impressions) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Create(&impressions)
+	if result.Error != nil {
+		r.logger.Error("Failed to save promo impressions", "count", len(impressions), "error", result.Error)
+		return fmt.Errorf("failed to save promo impressions: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *promoImpressionRepository) GetLatestImpression(ctx context.Context, clientID int64, promoID string) (*models.PromoImpression, error) {
+	if clientID <= 0 {
+		return nil, domainerrors.ErrInvalidClientID
+	}
+
+	var impression models.PromoImpression
+	result := r.db.WithContext(ctx).
+		Where("client_id = ? AND promo_id = ?", clientID, promoID).
+		Order("created_at DESC, id DESC").
+		First(&impression)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrImpressionNotFound
+		}
+		r.logger.Error("Failed to get promo impression", "client_id", clientID, "promo_id", promoID, "error", result.Error)
+		return nil, fmt.Errorf("failed to get promo impression: %w", result.Error)
+	}
+
+	return &impression, nil
+}
+
+// RecordOutcome меняет статус только у показа без ответа, чтобы
+// параллельные accept/decline не перезаписывали друг друга
+func (r *promoImpressionRepository) RecordOutcome(ctx context.Context, id int64, status string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.PromoImpression{}).
+		Where("id = ? AND status = ?", id, models.ImpressionShown).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": at,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to record promo outcome", "id", id, "status", status, "error", result.Error)
+		return false, fmt.Errorf("failed to record promo outcome: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *promoImpressionRepository) Stats(ctx context.Context, from, to time.Time, bandBounds []float64) ([]models.PromoImpressionStat, error) {
+	bounds := make([]string, 0, len(bandBounds))
+	for _, bound := range bandBounds {
+		bounds = append(bounds, strconv.FormatFloat(bound, 'f', -1, 64))
+	}
+
+	var stats []models.PromoImpressionStat
+	result := r.db.WithContext(ctx).
+		Model(&models.PromoImpression{}).
+		Select(`promo_id,
+			MAX(category) AS category,
+			MAX(title) AS title,
+			width_bucket(predict_income, ?::float8[]) AS band,
+			COUNT(*) AS impressions,
+			COUNT(*) FILTER (WHERE status = ?) AS accepted,
+			COUNT(*) FILTER (WHERE status = ?) AS declined`,
+			"{"+strings.Join(bounds, ",")+"}", models.ImpressionAccepted, models.ImpressionDeclined).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("promo_id, band").
+		Order("promo_id ASC, band ASC").
+		Scan(&stats)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to aggregate promo impressions", "error", result.Error)
+		return nil, fmt.Errorf("failed to aggregate promo impressions: %w", result.Error)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,367 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type promoRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewPromoRepository(db *gorm.DB, logger interfaces.Logger) interfaces.PromoRepository {
+	return &promoRepository{
+		db:     db,
+		logger: logger.With("component", "PromoRepository"),
+	}
+}
+
+func (r *promoRepository) ListCategories(ctx context.Context) ([]models.PromoCategory, error) {
+	var categories []models.PromoCategory
+	result := r.db.WithContext(ctx).
+		Preload("Bands", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_income ASC")
+		}).
+		Order("sort_order ASC, id ASC").
+		Find(&categories)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list promo categories", "error", result.Error)
+		return nil, fmt.Errorf("failed to list promo categories: %w", result.Error)
+	}
+
+	return categories, nil
+}
+
+func (r *promoRepository) GetCategory(ctx context.Context, id int64) (*models.PromoCategory, error) {
+	if id <= 0 {
+		return nil, domainerrors.ErrPromoNotFound
+	}
+
+	return r.getCategory(ctx, "id = ?", id)
+}
+
+func (r *promoRepository) GetCategoryByCode(ctx context.Context, code string) (*models.PromoCategory, error) {
+	return r.getCategory(ctx, "code = ?", code)
+}
+
+func (r *promoRepository) getCategory(ctx context.Context, query string, arg interface{}) (*models.PromoCategory, error) {
+	var category models.PromoCategory
+	result := r.db.WithContext(ctx).
+		Preload("Bands", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_income ASC")
+		}).
+		Where(query, arg).
+		First(&category)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrPromoNotFound
+		}
+		r.logger.Error("Failed to get promo category", "query", query, "arg", arg, "error", result.Error)
+		return nil, fmt.Errorf("failed to get promo category: %w", result.Error)
+	}
+
+	return &category, nil
+}
+
+func (r *promoRepository) CreateCategory(ctx context.Context, category *models.PromoCategory) error {
+	if category == nil {
+		return fmt.Errorf("promo category cannot be nil")
+	}
+
+	r.logger.Debug("Creating promo category", "code", category.Code, "bands", len(category.Bands))
+
+	result := r.db.WithContext(ctx).Create(category)
+	if result.Error != nil {
+		r.logger.Error("Failed to create promo category", "code", category.Code, "error", result.Error)
+		return fmt.Errorf("failed to create promo category: %w", result.Error)
+	}
+
+	r.logger.Info("Promo category created", "id", category.ID, "code", category.Code)
+	return nil
+}
+
+func (r *promoRepository) UpdateCategory(ctx context.Context, category *models.PromoCategory) error {
+	if category == nil {
+		return fmt.Errorf("promo category cannot be nil")
+	}
+
+	r.logger.Debug("Updating promo category", "id", category.ID, "bands", len(category.Bands))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PromoCategory{ID: category.ID}).
+			Select("Code", "Name", "Kind", "SortOrder", "UpdatedAt").
+			Updates(category)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update promo category: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrPromoNotFound
+		}
+
+		// диапазоны обновляются по id, чтобы id рекомендаций band-<id> и
+		// статистика показов не менялись от правки каталога
+		keep := make([]int64, 0, len(category.Bands))
+		for _, band := range category.Bands {
+			if band.ID > 0 {
+				keep = append(keep, band.ID)
+			}
+		}
+		removed := tx.Where("category_id = ?", category.ID)
+		if len(keep) > 0 {
+			removed = removed.Where("id NOT IN ?", keep)
+		}
+		if err := removed.Delete(&models.PromoBand{}).Error; err != nil {
+			return fmt.Errorf("failed to delete promo bands: %w", err)
+		}
+
+		var created []models.PromoBand
+		for i := range category.Bands {
+			band := &category.Bands[i]
+			band.CategoryID = category.ID
+			if band.ID == 0 {
+				created = append(created, *band)
+				continue
+			}
+			result := tx.Model(&models.PromoBand{ID: band.ID}).
+				Where("category_id = ?", category.ID).
+				Select("MinIncome", "MaxIncome", "Promo", "Description", "CTAURL", "UpdatedAt").
+				Updates(band)
+			if result.Error != nil {
+				return fmt.Errorf("failed to update promo band %d: %w", band.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("promo band %d does not belong to category %d", band.ID, category.ID)
+			}
+		}
+		if len(created) > 0 {
+			if err := tx.Create(&created).Error; err != nil {
+				return fmt.Errorf("failed to create promo bands: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrPromoNotFound) {
+			r.logger.Error("Failed to update promo category", "id", category.ID, "error", err)
+		}
+		return err
+	}
+
+	r.logger.Info("Promo category updated", "id", category.ID)
+	return nil
+}
+
+func (r *promoRepository) DeleteCategory(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainerrors.ErrPromoNotFound
+	}
+
+	r.logger.Debug("Deleting promo category", "id", id)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", id).Delete(&models.PromoBand{}).Error; err != nil {
+			return fmt.Errorf("failed to delete promo bands: %w", err)
+		}
+
+		result := tx.Delete(&models.PromoCategory{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete promo category: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrPromoNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrPromoNotFound) {
+			r.logger.Error("Failed to delete promo category", "id", id, "error", err)
+		}
+		return err
+	}
+
+	r.logger.Info("Promo category deleted", "id", id)
+	return nil
+}
+
+func (r *promoRepository) SeedIfEmpty(ctx context.Context, categories []models.PromoCategory) (bool, error) {
+	var seeded bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.PromoCategory{}).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count promo categories: %w", err)
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(&categories).Error; err != nil {
+			return fmt.Errorf("failed to seed promo catalog: %w", err)
+		}
+		seeded = true
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to seed promo catalog", "error", err)
+		return false, err
+	}
+
+	if seeded {
+		r.logger.Info("Promo catalog seeded", "categories", len(categories))
+	}
+	return seeded, nil
+}
+
+func (r *promoRepository) ListCampaigns(ctx context.Context) ([]models.PromoCampaign, error) {
+	var campaigns []models.PromoCampaign
+	result := r.db.WithContext(ctx).
+		Order("category_id ASC, priority DESC, id ASC").
+		Find(&campaigns)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list promo campaigns", "error", result.Error)
+		return nil, fmt.Errorf("failed to list promo campaigns: %w", result.Error)
+	}
+
+	return campaigns, nil
+}
+
+func (r *promoRepository) ListLiveCampaigns(ctx context.Context, now time.Time) ([]models.PromoCampaign, error) {
+	var campaigns []models.PromoCampaign
+	result := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Order("priority DESC, id ASC").
+		Find(&campaigns)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list live promo campaigns", "error", result.Error)
+		return nil, fmt.Errorf("failed to list live promo campaigns: %w", result.Error)
+	}
+
+	return campaigns, nil
+}
+
+func (r *promoRepository) GetCampaign(ctx context.Context, id int64) (*models.PromoCampaign, error) {
+	if id <= 0 {
+		return nil, domainerrors.ErrCampaignNotFound
+	}
+
+	var campaign models.PromoCampaign
+	result := r.db.WithContext(ctx).First(&campaign, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrCampaignNotFound
+		}
+		r.logger.Error("Failed to get promo campaign", "id", id, "error", result.Error)
+		return nil, fmt.Errorf("failed to get promo campaign: %w", result.Error)
+	}
+
+	return &campaign, nil
+}
+
+func (r *promoRepository) CreateCampaign(ctx context.Context, campaign *models.PromoCampaign) error {
+	if campaign == nil {
+		return fmt.Errorf("promo campaign cannot be nil")
+	}
+
+	r.logger.Debug("Creating promo campaign", "name", campaign.Name, "category_id", campaign.CategoryID)
+
+	result := r.db.WithContext(ctx).Create(campaign)
+	if result.Error != nil {
+		r.logger.Error("Failed to create promo campaign", "name", campaign.Name, "error", result.Error)
+		return fmt.Errorf("failed to create promo campaign: %w", result.Error)
+	}
+
+	r.logger.Info("Promo campaign created", "id", campaign.ID, "name", campaign.Name)
+	return nil
+}
+
+func (r *promoRepository) UpdateCampaign(ctx context.Context, campaign *models.PromoCampaign) error {
+	if campaign == nil {
+		return fmt.Errorf("promo campaign cannot be nil")
+	}
+
+	r.logger.Debug("Updating promo campaign", "id", campaign.ID)
+
+	// счетчик выдач не перезаписывается, его меняет только ReserveCampaign
+	result := r.db.WithContext(ctx).
+		Model(&models.PromoCampaign{ID: campaign.ID}).
+		Select("CategoryID", "Name", "Promo", "Description", "CTAURL", "StartsAt", "EndsAt", "Priority", "MaxIssued", "Enabled", "Targeting", "UpdatedAt").
+		Updates(campaign)
+	if result.Error != nil {
+		r.logger.Error("Failed to update promo campaign", "id", campaign.ID, "error", result.Error)
+		return fmt.Errorf("failed to update promo campaign: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrCampaignNotFound
+	}
+
+	r.logger.Info("Promo campaign updated", "id", campaign.ID)
+	return nil
+}
+
+func (r *promoRepository) DeleteCampaign(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainerrors.ErrCampaignNotFound
+	}
+
+	result := r.db.WithContext(ctx).Delete(&models.PromoCampaign{}, id)
+	if result.Error != nil {
+		r.logger.Error("Failed to delete promo campaign", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete promo campaign: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrCampaignNotFound
+	}
+
+	r.logger.Info("Promo campaign deleted", "id", id)
+	return nil
+}
+
+// errCampaignCapReached откатывает запись о выдаче, если лимит кампании исчерпан
+var errCampaignCapReached = errors.New("promo campaign cap reached")
+
+func (r *promoRepository) ReserveCampaign(ctx context.Context, id, clientID int64) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		issue := &models.PromoCampaignIssue{CampaignID: id, ClientID: clientID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(issue)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// кампания уже выдана этому клиенту
+			return nil
+		}
+
+		result = tx.Model(&models.PromoCampaign{}).
+			Where("id = ? AND (max_issued = 0 OR issued_count < max_issued)", id).
+			UpdateColumn("issued_count", gorm.Expr("issued_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errCampaignCapReached
+		}
+		return nil
+	})
+
+	if errors.Is(err, errCampaignCapReached) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to reserve promo campaign", "id", id, "client_id", clientID, "error", err)
+		return false, fmt.Errorf("failed to reserve promo campaign: %w", err)
+	}
+	return true, nil
+}
@@ -42,7 +42,19 @@ func NewPostgresRepository(cfg *config.Config, logger interfaces.Logger) (*gorm.
 func RunMigrations(db *gorm.DB, logger interfaces.Logger) error {
 	logger.Info("Running database migrations")
 
-	if err := db.AutoMigrate(&models.Client{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.Client{},
+		&models.ScoringRecord{},
+		&models.PromoCategory{},
+		&models.PromoBand{},
+		&models.PromoCampaign{},
+		&models.PromoCampaignIssue{},
+		&models.PromoImpression{},
+		&models.IncomeConfirmation{},
+		&models.TrainingSample{},
+		&models.DriftReference{},
+		&models.ShadowPrediction{},
+	); err != nil {
 		logger.Error("Failed to run migrations", "error", err)
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
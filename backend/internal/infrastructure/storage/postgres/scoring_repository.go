@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/Godrik0/HackChange-Alpha/backend/internal/domain/errors"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type scoringRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewScoringRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ScoringRepository {
+	return &scoringRepository{
+		db:     db,
+		logger: logger.With("component", "ScoringRepository"),
+	}
+}
+
+func (r *scoringRepository) Create(ctx context.Context, record *models.ScoringRecord) error {
+	if record == nil {
+		return fmt.Errorf("scoring record cannot be nil")
+	}
+
+	r.logger.Debug("Saving scoring result", "client_id", record.ClientID)
+
+	result := r.db.WithContext(ctx).Create(record)
+	if result.Error != nil {
+		r.logger.Error("Failed to save scoring result", "client_id", record.ClientID, "error", result.Error)
+		return fmt.Errorf("failed to save scoring result: %w", result.Error)
+	}
+
+	r.logger.Debug("Scoring result saved", "id", record.ID, "client_id", record.ClientID)
+	return nil
+}
+
+func (r *scoringRepository) GetLatestByClientID(ctx context.Context, clientID int64) (*models.ScoringRecord, error) {
+	if clientID <= 0 {
+		return nil, domainerrors.ErrInvalidClientID
+	}
+
+	r.logger.Debug("Getting latest scoring result", "client_id", clientID)
+
+	var record models.ScoringRecord
+	result := r.db.WithContext(ctx).
+		Where("client_id = ?", clientID).
+		Order("created_at DESC, id DESC").
+		First(&record)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrScoringNotFound
+		}
+		r.logger.Error("Failed to get latest scoring result", "client_id", clientID, "error", result.Error)
+		return nil, fmt.Errorf("failed to get latest scoring result: %w", result.Error)
+	}
+
+	return &record, nil
+}
+
+func (r *scoringRepository) ListByClientID(ctx context.Context, clientID int64, limit, offset int) ([]models.ScoringRecord, int64, error) {
+	if clientID <= 0 {
+		return nil, 0, domainerrors.ErrInvalidClientID
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	r.logger.Debug("Listing scoring results", "client_id", clientID, "limit", limit, "offset", offset)
+
+	var total int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.ScoringRecord{}).
+		Where("client_id = ?", clientID).
+		Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count scoring results", "client_id", clientID, "error", err)
+		return nil, 0, fmt.Errorf("failed to count scoring results: %w", err)
+	}
+
+	var records []models.ScoringRecord
+	result := r.db.WithContext(ctx).
+		Where("client_id = ?", clientID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&records)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list scoring results", "client_id", clientID, "error", result.Error)
+		return nil, 0, fmt.Errorf("failed to list scoring results: %w", result.Error)
+	}
+
+	return records, total, nil
+}
+
+func (r *scoringRepository) ListSnapshots(ctx context.Context, from, to time.Time, limit int) ([]models.ScoringRecord, error) {
+	var records []models.ScoringRecord
+	result := r.db.WithContext(ctx).
+		Select("id", "client_id", "predict_income", "features", "imputed_features", "created_at").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&records)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list scoring snapshots", "error", result.Error)
+		return nil, fmt.Errorf("failed to list scoring snapshots: %w", result.Error)
+	}
+
+	return records, nil
+}
+
+func (r *scoringRepository) VariantStats(ctx context.Context, from, to time.Time) ([]models.ModelVariantStat, error) {
+	var stats []models.ModelVariantStat
+	result := r.db.WithContext(ctx).
+		Model(&models.ScoringRecord{}).
+		Select(`COALESCE(NULLIF(model_variant, ''), ?) AS variant,
+			COUNT(*) AS scorings,
+			AVG(predict_income) AS avg_predict_income,
+			AVG(credit_limit) AS avg_credit_limit`,
+			models.DefaultModelVariant).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("variant").
+		Order("variant ASC").
+		Scan(&stats)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to aggregate scorings by model variant", "error", result.Error)
+		return nil, fmt.Errorf("failed to aggregate scorings by model variant: %w", result.Error)
+	}
+
+	return stats, nil
+}
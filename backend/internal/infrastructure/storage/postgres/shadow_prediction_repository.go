@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type shadowPredictionRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewShadowPredictionRepository(db *gorm.DB, logger interfaces.Logger) interfaces.ShadowPredictionRepository {
+	return &shadowPredictionRepository{
+		db:     db,
+		logger: logger.With("component", "ShadowPredictionRepository"),
+	}
+}
+
+func (r *shadowPredictionRepository) Create(ctx context.Context, prediction *models.ShadowPrediction) error {
+	if err := r.db.WithContext(ctx).Create(prediction).Error; err != nil {
+		r.logger.Error("Failed to save shadow prediction", "scoring_id", prediction.ScoringID, "error", err)
+		return fmt.Errorf("failed to save shadow prediction: %w", err)
+	}
+	return nil
+}
+
+func (r *shadowPredictionRepository) Compare(ctx context.Context, from, to time.Time, tolerance float64) ([]models.ShadowComparisonStat, error) {
+	var stats []models.ShadowComparisonStat
+	result := r.db.WithContext(ctx).
+		Table("shadow_predictions AS s").
+		Joins("JOIN scoring_results AS r ON r.id = s.scoring_id").
+		Select(`s.champion_variant,
+			s.variant AS challenger_variant,
+			COUNT(*) AS pairs,
+			AVG(CASE WHEN ABS(s.predict_income - r.predict_income) <= ? * GREATEST(ABS(r.predict_income), 1) THEN 1 ELSE 0 END) AS agreement_rate,
+			AVG(s.predict_income - r.predict_income) AS mean_income_diff,
+			AVG(ABS(s.predict_income - r.predict_income)) AS mean_abs_income_diff,
+			AVG(s.credit_limit - r.credit_limit) AS mean_limit_diff,
+			AVG(ABS(s.credit_limit - r.credit_limit)) AS mean_abs_limit_diff,
+			AVG(CASE WHEN s.credit_limit > r.credit_limit THEN 1 ELSE 0 END) AS higher_limit_rate,
+			AVG(CASE WHEN s.credit_limit < r.credit_limit THEN 1 ELSE 0 END) AS lower_limit_rate`,
+			tolerance).
+		Where("s.created_at >= ? AND s.created_at < ?", from, to).
+		Group("s.champion_variant, s.variant").
+		Order("s.champion_variant ASC, s.variant ASC").
+		Scan(&stats)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to compare shadow predictions", "error", result.Error)
+		return nil, fmt.Errorf("failed to compare shadow predictions: %w", result.Error)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/interfaces"
+	"github.com/Godrik0/HackChange-Alpha/backend/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type trainingRepository struct {
+	db     *gorm.DB
+	logger interfaces.Logger
+}
+
+func NewTrainingRepository(db *gorm.DB, logger interfaces.Logger) interfaces.TrainingRepository {
+	return &trainingRepository{
+		db:     db,
+		logger: logger.With("component", "TrainingRepository"),
+	}
+}
+
+func (r *trainingRepository) CreateConfirmation(ctx context.Context, confirmation *models.IncomeConfirmation, sample *models.TrainingSample) error {
+	if confirmation == nil {
+		return fmt.Errorf("income confirmation cannot be nil")
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(confirmation).Error; err != nil {
+			return fmt.Errorf("failed to save income confirmation: %w", err)
+		}
+		if sample == nil {
+			return nil
+		}
+
+		sample.ConfirmationID = confirmation.ID
+		if err := tx.Create(sample).Error; err != nil {
+			return fmt.Errorf("failed to save training sample: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to save income confirmation", "client_id", confirmation.ClientID, "error", err)
+		return err
+	}
+
+	r.logger.Debug("Income confirmation saved", "id", confirmation.ID, "client_id", confirmation.ClientID)
+	return nil
+}
+
+func (r *trainingRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.TrainingSample, error) {
+	var samples []models.TrainingSample
+	// SKIP LOCKED: строки, которые забирает параллельный экспортер, пропускаются
+	result := r.db.WithContext(ctx).Raw(`
+		UPDATE training_samples SET status = ?, next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM training_samples
+			WHERE status IN (?, ?) AND next_attempt_at <= ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.TrainingSending, now.Add(lease),
+		models.TrainingPending, models.TrainingSending, now,
+		limit,
+	).Scan(&samples)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to claim due training samples", "error", result.Error)
+		return nil, fmt.Errorf("failed to claim due training samples: %w", result.Error)
+	}
+
+	slices.SortFunc(samples, func(a, b models.TrainingSample) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return samples, nil
+}
+
+func (r *trainingRepository) MarkSent(ctx context.Context, ids []int64, batchID string, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TrainingSample{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     models.TrainingSent,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"batch_id":   batchID,
+			"sent_at":    at,
+			"last_error": "",
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to mark training samples sent", "batch_id", batchID, "error", result.Error)
+		return fmt.Errorf("failed to mark training samples sent: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) MarkFailed(ctx context.Context, ids []int64, batchID string, reason string, at time.Time, backoff time.Duration, maxAttempts int) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TrainingSample{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":          gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? ELSE ? END", maxAttempts, models.TrainingFailed, models.TrainingPending),
+			"attempts":        gorm.Expr("attempts + 1"),
+			"batch_id":        batchID,
+			"last_error":      reason,
+			"next_attempt_at": gorm.Expr("?::timestamptz + make_interval(secs => ? * power(2, attempts))", at, backoff.Seconds()),
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to mark training samples failed", "batch_id", batchID, "error", result.Error)
+		return fmt.Errorf("failed to mark training samples failed: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) CountByStatus(ctx context.Context) ([]models.TrainingDeliveryStat, error) {
+	var stats []models.TrainingDeliveryStat
+	result := r.db.WithContext(ctx).
+		Model(&models.TrainingSample{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&stats)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to count training samples", "error", result.Error)
+		return nil, fmt.Errorf("failed to count training samples: %w", result.Error)
+	}
+
+	return stats, nil
+}
+
+func (r *trainingRepository) LastSentAt(ctx context.Context) (*time.Time, error) {
+	var lastSent *time.Time
+	result := r.db.WithContext(ctx).
+		Model(&models.TrainingSample{}).
+		Select("MAX(sent_at)").
+		Scan(&lastSent)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to get last training delivery", "error", result.Error)
+		return nil, fmt.Errorf("failed to get last training delivery: %w", result.Error)
+	}
+
+	return lastSent, nil
+}
+
+func (r *trainingRepository) ListOutcomes(ctx context.Context, from, to time.Time, regionFeature string) ([]models.LabeledOutcome, error) {
+	var outcomes []models.LabeledOutcome
+	result := r.db.WithContext(ctx).
+		Table("training_samples AS s").
+		Select(`s.target, s.predict_income, s.model_version,
+			COALESCE(s.features ->> ?, '') AS region,
+			c.birth_date, ic.confirmed_at`, regionFeature).
+		Joins("JOIN income_confirmations AS ic ON ic.id = s.confirmation_id").
+		Joins("LEFT JOIN clients AS c ON c.id = s.client_id").
+		Where("ic.confirmed_at >= ? AND ic.confirmed_at < ?", from, to).
+		Scan(&outcomes)
+
+	if result.Error != nil {
+		r.logger.Error("Failed to list labeled outcomes", "error", result.Error)
+		return nil, fmt.Errorf("failed to list labeled outcomes: %w", result.Error)
+	}
+
+	return outcomes, nil
+}
@@ -1,3 +1,27 @@
+export interface Recommendation {
+  id: string;
+  category: 'promo' | 'card' | 'investment';
+  title: string;
+  description: string;
+  priority: number;
+  eligibility_reason: string;
+  cta_url?: string;
+}
+
+export interface Factor {
+  feature: string;
+  label_ru: string;
+  value: number | string | boolean | null;
+  contribution: number;
+}
+
+export interface FactorCategory {
+  category: string;
+  contribution: number;
+  share: number;
+  feature_count: number;
+}
+
 export interface Scoring {
   id: number;
   first_name: string;
@@ -8,7 +32,12 @@ export interface Scoring {
   predict_income: number;
   credit_limit: number;
   max_credit_limit?: number;
-  recommendations: string[];
-  positive_factors: string[];
-  negative_factors: string[];
+  recommendations: Recommendation[];
+  positive_factors: Factor[];
+  negative_factors: Factor[];
+  factor_categories?: FactorCategory[];
+  cached?: boolean;
+  scored_at?: string;
+  stale?: boolean;
+  stale_age_seconds?: number;
 }
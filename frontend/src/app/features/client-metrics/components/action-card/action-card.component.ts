@@ -1,6 +1,7 @@
 import {Component, Input} from '@angular/core';
 import {NzCardComponent} from "ng-zorro-antd/card";
 import {NzIconDirective} from "ng-zorro-antd/icon";
+import {Recommendation} from "@core/models/scoring";
 
 @Component({
   selector: 'app-action-card',
@@ -12,5 +13,5 @@ import {NzIconDirective} from "ng-zorro-antd/icon";
   styleUrl: './action-card.component.less',
 })
 export class ActionCardComponent {
-  @Input() recommendation!: string;
+  @Input() recommendation!: Recommendation;
 }
@@ -3,6 +3,7 @@ import {NzSplitterModule} from "ng-zorro-antd/splitter";
 import {NzFlexDirective} from "ng-zorro-antd/flex";
 import {NgForOf} from "@angular/common";
 import {NzTypographyComponent} from "ng-zorro-antd/typography";
+import {Factor} from "@core/models/scoring";
 
 @Component({
   selector: 'app-bullet-points',
@@ -17,7 +18,7 @@ import {NzTypographyComponent} from "ng-zorro-antd/typography";
 })
 export class BulletPointsComponent {
   @Input() points: {
-    negative_factors: string[];
-    positive_factors: string[];
+    negative_factors: Factor[];
+    positive_factors: Factor[];
   } = Object();
 }
@@ -13,7 +13,7 @@ import {getFullName} from "@core/utils/utils";
 import {Client} from "@core/models/client";
 import {DataService} from "@core/services/data.service";
 import {NzEmptyComponent} from "ng-zorro-antd/empty";
-import {Scoring} from "@core/models/scoring";
+import {Factor, Scoring} from "@core/models/scoring";
 import {Subject, takeUntil, switchMap} from "rxjs";
 
 @Component({
@@ -47,7 +47,8 @@ export class ClientMetricsComponent implements OnInit, OnDestroy {
   
   // Добавляем поля для хранения данных, чтобы не пересчитывать их в геттерах
   incomeData: any[] = []; 
-  bulletPoints: { negative_factors: string[], positive_factors: string[] } = { negative_factors: [], positive_factors: [] };
+  bulletPoints: { negative_factors: Factor[], positive_factors: Factor[] } = { negative_factors: [], positive_factors: [] };
+  factorSummary: string = '';
   fullName: string = '';
   selectedLimit: number = 0;
 
@@ -119,6 +120,11 @@ export class ClientMetricsComponent implements OnInit, OnDestroy {
       positive_factors: this.scoring.positive_factors || []
     };
 
+    // Вклад категорий признаков: "Кредитная история +12%, Зарплатные данные −5%"
+    this.factorSummary = (this.scoring.factor_categories || [])
+      .map(c => `${c.category} ${c.share >= 0 ? '+' : '−'}${Math.round(Math.abs(c.share) * 100)}%`)
+      .join(', ');
+
     // 3. Фиксируем имя
     this.fullName = getFullName({
       first_name: this.scoring.first_name,